@@ -9,9 +9,22 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/sahil/ats-resume-maker/backend/internal/handlers"
+	"github.com/sahil/ats-resume-maker/backend/internal/store"
 )
 
 func main() {
+	// Saved resumes live in an embedded SQLite database
+	dbPath := os.Getenv("DATABASE_PATH")
+	if dbPath == "" {
+		dbPath = "./data/resumes.db"
+	}
+	resumeStore, err := store.OpenSQLite(dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open resume store: %v", err)
+	}
+	defer resumeStore.Close()
+	handlers.SetStore(resumeStore)
+
 	r := gin.Default()
 
 	// CORS configuration - Allow GitHub Pages and local development
@@ -22,8 +35,8 @@ func main() {
 			"https://sahilgogna.github.io",
 		},
 		AllowMethods:  []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders: []string{"Content-Length", "Content-Type"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization", "If-Match", "If-None-Match"},
+		ExposeHeaders: []string{"Content-Length", "Content-Type", "ETag", "Location"},
 		MaxAge:        12 * time.Hour,
 	}))
 
@@ -38,6 +51,51 @@ func main() {
 	// Resume compilation endpoint
 	r.POST("/api/compile-resume", handlers.CompileResume)
 
+	// Cover letter compilation endpoint
+	r.POST("/api/compile-cover-letter", handlers.CompileCoverLetter)
+
+	// Batch compilation endpoint
+	r.POST("/api/compile-batch", handlers.CompileBatch)
+
+	// Background compile job endpoints
+	r.POST("/api/jobs", handlers.CreateJob)
+	r.GET("/api/jobs/:id", handlers.GetJob)
+	r.DELETE("/api/jobs/:id", handlers.CancelJob)
+	r.GET("/api/jobs/:id/events", handlers.JobEvents)
+	r.GET("/api/jobs/:id/download", handlers.DownloadJob)
+
+	// Job description keyword match endpoint
+	r.POST("/api/analyze", handlers.AnalyzeResume)
+
+	// ATS and style lint endpoint
+	r.POST("/api/lint", handlers.LintResume)
+
+	// Resume import endpoints
+	r.POST("/api/import/pdf", handlers.ImportPDF)
+	r.POST("/api/import/linkedin", handlers.ImportLinkedIn)
+	r.POST("/api/import/latex", handlers.ImportLaTeX)
+
+	// Saved resume endpoints
+	r.POST("/api/resumes", handlers.CreateResume)
+	r.GET("/api/resumes/:id", handlers.GetResume)
+	r.PUT("/api/resumes/:id", handlers.UpdateResume)
+	r.DELETE("/api/resumes/:id", handlers.DeleteResume)
+	r.POST("/api/resumes/:id/compile", handlers.CompileSavedResume)
+
+	// Resume revision history endpoints
+	r.GET("/api/resumes/:id/revisions", handlers.ListRevisions)
+	r.GET("/api/resumes/:id/revisions/:version", handlers.GetRevision)
+	r.POST("/api/resumes/:id/revisions/:version/restore", handlers.RestoreRevision)
+	r.GET("/api/resumes/:id/diff", handlers.DiffRevisions)
+
+	// Per-application variant endpoints
+	r.POST("/api/resumes/:id/variants", handlers.CreateVariant)
+	r.GET("/api/resumes/:id/variants", handlers.ListVariants)
+	r.GET("/api/resumes/:id/variants/:variantId", handlers.GetVariant)
+	r.PUT("/api/resumes/:id/variants/:variantId", handlers.UpdateVariant)
+	r.DELETE("/api/resumes/:id/variants/:variantId", handlers.DeleteVariant)
+	r.POST("/api/resumes/:id/variants/:variantId/compile", handlers.CompileVariant)
+
 	// PDF download endpoint
 	r.GET("/api/download/:filename", handlers.DownloadPDF)
 
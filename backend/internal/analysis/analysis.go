@@ -0,0 +1,260 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+const (
+	maxSkills  = 30 // Skills kept from a job description
+	maxPhrases = 15 // Non-skill keywords kept from a job description
+	maxNGram   = 3  // Longest keyword phrase, in words
+)
+
+// term is a keyword candidate extracted from a job description
+type term struct {
+	key     string // Lowercase canonical skill, or space-joined stems
+	display string
+	skill   bool
+	words   int
+	count   int
+	first   int // Order of first occurrence, for stable ranking
+}
+
+// Analyze extracts keywords from a job description and reports which resume
+// sections mention them. Matching is offline and English-only: skills match
+// through the normalization dictionary, other keywords by their stems.
+func Analyze(req *models.ResumeRequest, jobDescription string) *models.AnalysisReport {
+	terms := extractTerms(jobDescription)
+
+	locale, ok := i18n.Lookup(req.Locale)
+	if !ok {
+		locale = i18n.Default()
+	}
+
+	report := &models.AnalysisReport{
+		Keywords: make([]models.KeywordMatch, len(terms)),
+		Missing:  []string{},
+		Sections: make([]models.SectionCoverage, len(req.Sections)),
+	}
+	for i, t := range terms {
+		report.Keywords[i] = models.KeywordMatch{Keyword: t.display, Skill: t.skill, Count: t.count, Sections: []string{}}
+	}
+
+	for i, section := range req.Sections {
+		title := section.Title
+		if title == "" {
+			title = locale.Heading(section.Type)
+		}
+		if title == "" {
+			title = section.Type
+		}
+		coverage := models.SectionCoverage{Type: section.Type, Title: title, Keywords: []string{}}
+
+		idx := newIndex(collectText(section.Content))
+		for j, t := range terms {
+			if idx.contains(t) {
+				coverage.Keywords = append(coverage.Keywords, t.display)
+				report.Keywords[j].Sections = append(report.Keywords[j].Sections, title)
+			}
+		}
+		report.Sections[i] = coverage
+	}
+
+	// Skills weigh double and repeated keywords up to three times
+	var total, matched float64
+	for i, t := range terms {
+		weight := float64(min(t.count, 3))
+		if t.skill {
+			weight *= 2
+		}
+		total += weight
+		if len(report.Keywords[i].Sections) > 0 {
+			matched += weight
+		} else {
+			report.Missing = append(report.Missing, t.display)
+		}
+	}
+	if total > 0 {
+		report.Score = int(math.Round(100 * matched / total))
+	}
+
+	return report
+}
+
+// extractTerms returns the skills and the most frequent phrases of a job
+// description, skills first, each ranked by frequency
+func extractTerms(text string) []term {
+	// Skills and phrases are keyed apart, as "go" the verb is not "Go"
+	type termKey struct {
+		key   string
+		skill bool
+	}
+	found := make(map[termKey]*term)
+	order := 0
+	add := func(key, display string, skill bool, words int) {
+		if t, ok := found[termKey{key, skill}]; ok {
+			t.count++
+			return
+		}
+		found[termKey{key, skill}] = &term{key: key, display: display, skill: skill, words: words, count: 1, first: order}
+		order++
+	}
+
+	for _, phrase := range phrases(text) {
+		// Skills and ignorable words split a phrase into runs of keyword words
+		var run []word
+		flush := func() {
+			for n := 1; n <= maxNGram; n++ {
+				for i := 0; i+n <= len(run); i++ {
+					gram := run[i : i+n]
+					if leadingVerbs[gram[0].lower] {
+						continue
+					}
+					display := make([]string, n)
+					for j, w := range gram {
+						display[j] = w.lower
+					}
+					add(stemKey(gram), strings.Join(display, " "), false, n)
+				}
+			}
+			run = nil
+		}
+
+		for i := 0; i < len(phrase); {
+			if skill, n := skillAt(phrase, i); n > 0 {
+				flush()
+				add(strings.ToLower(skill), skill, true, n)
+				i += n
+				continue
+			}
+			if ignorable(phrase[i].lower) {
+				flush()
+			} else {
+				run = append(run, phrase[i])
+			}
+			i++
+		}
+		flush()
+	}
+
+	var skillTerms, phraseTerms []*term
+	for _, t := range found {
+		switch {
+		case t.skill:
+			skillTerms = append(skillTerms, t)
+		case t.words == 1 || t.count >= 2: // Multi-word phrases must repeat
+			phraseTerms = append(phraseTerms, t)
+		}
+	}
+	rank(skillTerms)
+	rank(phraseTerms)
+	if len(skillTerms) > maxSkills {
+		skillTerms = skillTerms[:maxSkills]
+	}
+
+	// Drop words already covered by an at least as frequent phrase, e.g.
+	// "distributed" next to "distributed systems"
+	var kept []*term
+	for _, t := range phraseTerms {
+		if len(kept) == maxPhrases {
+			break
+		}
+		if !subsumed(t, phraseTerms) {
+			kept = append(kept, t)
+		}
+	}
+
+	var result []term
+	for _, t := range append(skillTerms, kept...) {
+		result = append(result, *t)
+	}
+	return result
+}
+
+// rank orders terms by frequency, then length, then first occurrence
+func rank(terms []*term) {
+	sort.Slice(terms, func(i, j int) bool {
+		a, b := terms[i], terms[j]
+		if a.count != b.count {
+			return a.count > b.count
+		}
+		if a.words != b.words {
+			return a.words > b.words
+		}
+		return a.first < b.first
+	})
+}
+
+// subsumed reports whether a longer, at least as frequent phrase contains t
+func subsumed(t *term, terms []*term) bool {
+	for _, other := range terms {
+		if other.words > t.words && other.count >= t.count && other.count >= 2 &&
+			strings.Contains(" "+other.key+" ", " "+t.key+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// index holds the skills and stem n-grams found in a resume section
+type index struct {
+	skills map[string]bool
+	grams  map[string]bool
+}
+
+func newIndex(texts []string) *index {
+	idx := &index{skills: make(map[string]bool), grams: make(map[string]bool)}
+	for _, text := range texts {
+		for _, phrase := range phrases(text) {
+			for i := range phrase {
+				if skill, n := skillAt(phrase, i); n > 0 {
+					idx.skills[strings.ToLower(skill)] = true
+				}
+				for n := 1; n <= maxNGram && i+n <= len(phrase); n++ {
+					idx.grams[stemKey(phrase[i:i+n])] = true
+				}
+			}
+		}
+	}
+	return idx
+}
+
+func (idx *index) contains(t term) bool {
+	if t.skill {
+		return idx.skills[t.key]
+	}
+	return idx.grams[t.key]
+}
+
+// nonTextKeys are content fields that hold URLs, dates or settings rather
+// than prose
+var nonTextKeys = map[string]bool{
+	"url": true, "kind": true, "layout": true, "techStyle": true, "doi": true,
+	"startDate": true, "endDate": true, "date": true, "expiryDate": true, "year": true,
+	"gpa": true, "gpaScale": true, "id": true, "bulletIds": true,
+}
+
+// collectText gathers the prose strings of arbitrary section content
+func collectText(content interface{}) []string {
+	var texts []string
+	switch v := content.(type) {
+	case string:
+		texts = append(texts, v)
+	case []interface{}:
+		for _, item := range v {
+			texts = append(texts, collectText(item)...)
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			if !nonTextKeys[key] {
+				texts = append(texts, collectText(item)...)
+			}
+		}
+	}
+	return texts
+}
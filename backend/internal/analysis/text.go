@@ -0,0 +1,186 @@
+package analysis
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/skills"
+)
+
+// word is a token with its original spelling, which decides whether an
+// ambiguous skill such as "Go" or "REST" is meant
+type word struct {
+	text  string
+	lower string
+}
+
+var (
+	// phraseBreak splits text at punctuation that ends a phrase, so n-grams
+	// never span it. A period only breaks when followed by whitespace, which
+	// keeps "Node.js" and ".NET" intact.
+	phraseBreak = regexp.MustCompile(`[,;:!?()\[\]{}"“”•·|\n\r]+|\.(\s|$)|\s[-–—/]\s`)
+	wordRegex   = regexp.MustCompile(`\.?[\p{L}\p{N}][\p{L}\p{N}+#./'-]*`)
+)
+
+// phrases splits text into runs of words separated by punctuation. Slashed
+// pairs such as "frontend/backend" are split unless they name a skill.
+func phrases(text string) [][]word {
+	var result [][]word
+	for _, part := range phraseBreak.Split(text, -1) {
+		var words []word
+		for _, w := range wordRegex.FindAllString(part, -1) {
+			w = strings.TrimRight(strings.TrimSuffix(w, "'s"), "./'-")
+			if w == "" {
+				continue
+			}
+			if strings.Contains(w, "/") {
+				if _, ok := skills.Lookup(w); !ok {
+					for _, p := range strings.Split(w, "/") {
+						if p != "" {
+							words = append(words, word{text: p, lower: strings.ToLower(p)})
+						}
+					}
+					continue
+				}
+			}
+			words = append(words, word{text: w, lower: strings.ToLower(w)})
+		}
+		if len(words) > 0 {
+			result = append(result, words)
+		}
+	}
+	return result
+}
+
+// ambiguousSkills are dictionary aliases that are also everyday English
+// words. They only count as skills when not written in lowercase.
+var ambiguousSkills = map[string]bool{
+	"go": true, "r": true, "rest": true, "express": true, "gin": true,
+	"excel": true, "swift": true, "rust": true, "ruby": true,
+}
+
+// maxSkillWords is the longest alias in the skills dictionary, in words
+const maxSkillWords = 3
+
+// skillAt reports the canonical skill starting at words[i] and how many
+// words it spans, preferring the longest alias
+func skillAt(words []word, i int) (string, int) {
+	for n := min(maxSkillWords, len(words)-i); n > 0; n-- {
+		parts := make([]string, n)
+		for j := range parts {
+			parts[j] = words[i+j].lower
+		}
+		alias := strings.Join(parts, " ")
+		canonical, ok := skills.Lookup(alias)
+		if !ok {
+			continue
+		}
+		if n == 1 && ambiguousSkills[alias] && words[i].text == words[i].lower {
+			continue
+		}
+		return canonical, n
+	}
+	return "", 0
+}
+
+// ignorable reports whether a word carries no keyword meaning on its own
+func ignorable(w string) bool {
+	if len([]rune(w)) < 2 || stopwords[w] {
+		return true
+	}
+	return !strings.ContainsFunc(w, unicode.IsLetter)
+}
+
+// stopwords are common English words plus job posting boilerplate
+var stopwords = toSet(`a about above across after again against all also am an and any are as at
+be because been before being below between both but by can could did do does doing down during
+each either etc few for from further had has have having he her here hers him his how i if in
+into is it its itself just me more most must my no nor not now of off on once only or other our
+ours out over own per same shall she should so some such than that the their theirs them then
+there these they this those through to too under until up upon us very via was we were what when
+where which while who whom why will with within without would you your yours yourself
+
+ability able across applicant applicants apply benefits bonus candidate candidates company
+compensation day days degree demonstrated desire desired equal employer environment etc excellent
+experience experienced familiarity familiar full good great help highly ideal ideally including
+job join key knowledge least looking minimum new nice opportunity plus position preferred
+proficiency proficient qualification qualifications related relevant requirement requirements
+required responsibilities responsibility role salary seeking skill skills strong team teams time
+understanding using well work working world year years
+
+beyond best get go junior mid mid-level principal senior staff`)
+
+// leadingVerbs are verbs that commonly open a job posting phrase. A keyword
+// never starts with one, so "build distributed systems" yields
+// "distributed systems" rather than "build".
+var leadingVerbs = toSet(`architect build building collaborate create creating deliver
+delivering deploy design designing develop developing drive driving ensure help implement
+implementing improve improving lead leading maintain maintaining manage managing own partner
+scale support supporting use write writing`)
+
+func toSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// suffixes are stripped in order, first match wins, by a light
+// Porter-style stemmer
+var suffixes = []struct{ suffix, replacement string }{
+	{"sses", "ss"},
+	{"ies", "y"},
+	{"ational", "ate"},
+	{"ization", "ize"},
+	{"ation", "ate"},
+	{"ment", ""},
+	{"ness", ""},
+	{"ing", ""},
+	{"ed", ""},
+	{"er", ""},
+	{"ly", ""},
+	{"s", ""},
+}
+
+// stem reduces a lowercase word to a stem shared by its inflections, e.g.
+// "managing", "managed", "manager" and "management" all become "manag". The
+// stems are only compared with each other, never shown.
+func stem(w string) string {
+	if len(w) <= 3 || strings.ContainsAny(w, "+#./") {
+		return w
+	}
+
+	// Two passes strip stacked suffixes, e.g. "engineering" -> "engineer" -> "engine"
+	for pass := 0; pass < 2; pass++ {
+		for _, s := range suffixes {
+			if !strings.HasSuffix(w, s.suffix) || len(w)-len(s.suffix) < 3 {
+				continue
+			}
+			if s.suffix == "s" && (strings.HasSuffix(w, "ss") || strings.HasSuffix(w, "us") || strings.HasSuffix(w, "is")) {
+				continue
+			}
+			w = w[:len(w)-len(s.suffix)] + s.replacement
+			break
+		}
+	}
+
+	if len(w) > 3 && strings.HasSuffix(w, "e") {
+		w = w[:len(w)-1]
+	}
+	// Undouble a final consonant, e.g. "runn" -> "run"
+	if n := len(w); n > 3 && w[n-1] == w[n-2] && !strings.ContainsRune("aeiouls", rune(w[n-1])) {
+		w = w[:n-1]
+	}
+	return w
+}
+
+// stemKey joins the stems of a word sequence into a lookup key
+func stemKey(words []word) string {
+	stems := make([]string, len(words))
+	for i, w := range words {
+		stems[i] = stem(w.lower)
+	}
+	return strings.Join(stems, " ")
+}
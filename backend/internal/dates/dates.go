@@ -0,0 +1,330 @@
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+)
+
+// Style controls how dates are rendered
+type Style string
+
+const (
+	StyleShort   Style = "short"   // "Jan 2020 – Present"
+	StyleLong    Style = "long"    // "January 2020 – Present"
+	StyleNumeric Style = "numeric" // "01/2020 – 03/2023"
+	StyleYear    Style = "year"    // "2020–2023"
+)
+
+// DefaultStyle is used when a request does not specify one
+const DefaultStyle = StyleShort
+
+// ParseStyle validates a style name. An empty name resolves to the default.
+func ParseStyle(s string) (Style, bool) {
+	switch Style(s) {
+	case "":
+		return DefaultStyle, true
+	case StyleShort, StyleLong, StyleNumeric, StyleYear:
+		return Style(s), true
+	}
+	return "", false
+}
+
+// Date is a month-precision resume date
+type Date struct {
+	Year     int
+	Month    time.Month // Zero when only the year is known
+	Present  bool       // Ongoing, rendered as "Present"
+	Expected bool       // Future date, e.g. an expected graduation
+}
+
+var (
+	isoRegex     = regexp.MustCompile(`^(\d{4})-(\d{1,2})(?:-\d{1,2})?$`)
+	numericRegex = regexp.MustCompile(`^(\d{1,2})[/.](\d{4})$`)
+	yearRegex    = regexp.MustCompile(`^(\d{4})$`)
+	monthRegex   = regexp.MustCompile(`^([A-Za-z]+)\.?,?\s+(\d{4})$`)
+)
+
+// monthNames maps lowercase English month names and abbreviations to months
+var monthNames = map[string]time.Month{}
+
+func init() {
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		monthNames[name] = m
+		monthNames[name[:3]] = m
+	}
+	monthNames["sept"] = time.September
+}
+
+// Parse accepts "2020-01", "01/2020", "2020", "Jan 2020", "January 2020",
+// "Present" and an optional "Expected" prefix. An empty string yields the
+// zero Date.
+func Parse(s string) (Date, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Date{}, nil
+	}
+
+	switch strings.ToLower(s) {
+	case "present", "current", "now", "ongoing":
+		return Date{Present: true}, nil
+	}
+
+	var d Date
+	hasMonth := true
+	if rest, ok := cutPrefixFold(s, "expected "); ok {
+		d.Expected = true
+		s = strings.TrimSpace(rest)
+	}
+
+	if m := isoRegex.FindStringSubmatch(s); m != nil {
+		d.Year, _ = strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		d.Month = time.Month(month)
+	} else if m := numericRegex.FindStringSubmatch(s); m != nil {
+		month, _ := strconv.Atoi(m[1])
+		d.Month = time.Month(month)
+		d.Year, _ = strconv.Atoi(m[2])
+	} else if m := yearRegex.FindStringSubmatch(s); m != nil {
+		d.Year, _ = strconv.Atoi(m[1])
+		hasMonth = false
+	} else if m := monthRegex.FindStringSubmatch(s); m != nil {
+		month, ok := monthNames[strings.ToLower(m[1])]
+		if !ok {
+			return Date{}, fmt.Errorf("unrecognized month %q", m[1])
+		}
+		d.Month = month
+		d.Year, _ = strconv.Atoi(m[2])
+	} else {
+		return Date{}, fmt.Errorf("unrecognized date %q, use e.g. \"Jan 2020\", \"2020-01\" or \"Present\"", s)
+	}
+
+	if (hasMonth && d.Month < time.January) || d.Month > time.December {
+		return Date{}, fmt.Errorf("invalid month in %q", s)
+	}
+	if d.Year < 1900 || d.Year > 2100 {
+		return Date{}, fmt.Errorf("year out of range in %q", s)
+	}
+	return d, nil
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
+
+// IsZero reports whether the date is unset
+func (d Date) IsZero() bool {
+	return d.Year == 0 && !d.Present
+}
+
+// Compare returns -1, 0 or +1 depending on whether d is before, equal to or
+// after o. Present sorts after every concrete date, and a year-only date
+// sorts before the months of its year so the order stays transitive.
+func (d Date) Compare(o Date) int {
+	switch {
+	case d.Present && o.Present:
+		return 0
+	case d.Present:
+		return 1
+	case o.Present:
+		return -1
+	}
+	if d.Year != o.Year {
+		return cmpInt(d.Year, o.Year)
+	}
+	return cmpInt(int(d.Month), int(o.Month))
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Format renders the date in the given style and locale
+func (d Date) Format(style Style, loc *i18n.Locale) string {
+	if d.Present {
+		return capitalize(loc.Present)
+	}
+	if d.IsZero() {
+		return ""
+	}
+
+	year := strconv.Itoa(d.Year)
+	var s string
+	switch {
+	case d.Month == 0 || style == StyleYear:
+		s = year
+	case style == StyleLong:
+		s = loc.MonthName(d.Month, false) + " " + year
+	case style == StyleNumeric:
+		s = fmt.Sprintf("%02d/%s", int(d.Month), year)
+	default:
+		s = loc.MonthName(d.Month, true) + " " + year
+	}
+
+	if d.Expected {
+		s = loc.Expected + " " + s
+	}
+	return s
+}
+
+// capitalize upper-cases the first letter, e.g. "aujourd'hui" at the start of
+// a date range
+func capitalize(s string) string {
+	for i, r := range s {
+		return strings.ToUpper(string(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
+
+// Range is a start/end pair of dates
+type Range struct {
+	Start Date
+	End   Date
+}
+
+// ParseRange parses both ends of a range. A current flag marks the end as
+// Present, overriding any end date given. It fails when the end precedes
+// the start.
+func ParseRange(start, end string, current bool) (Range, error) {
+	var r Range
+	var err error
+	if r.Start, err = Parse(start); err != nil {
+		return Range{}, fmt.Errorf("start date: %w", err)
+	}
+	if current {
+		r.End = Date{Present: true}
+	} else if r.End, err = Parse(end); err != nil {
+		return Range{}, fmt.Errorf("end date: %w", err)
+	}
+	if r.Start.Present {
+		return Range{}, fmt.Errorf("start date cannot be %q", start)
+	}
+	if !r.Start.IsZero() && !r.End.IsZero() && endsBefore(r.End, r.Start) {
+		return Range{}, fmt.Errorf("end date %q is before start date %q", end, start)
+	}
+	return r, nil
+}
+
+// endsBefore reports whether end is before start. A year-only date covers
+// its whole year, so it is only before dates in later years.
+func endsBefore(end, start Date) bool {
+	if !end.Present && (end.Month == 0 || start.Month == 0) {
+		return end.Year < start.Year
+	}
+	return end.Compare(start) < 0
+}
+
+// Format renders the range, collapsing it when only one end is set or both
+// ends fall in the same period
+func (r Range) Format(style Style, loc *i18n.Locale) string {
+	start := r.Start.Format(style, loc)
+	end := r.End.Format(style, loc)
+
+	switch {
+	case start == "":
+		return end
+	case end == "" || start == end:
+		return start
+	case style == StyleYear:
+		return start + "–" + end
+	}
+	return start + " – " + end
+}
+
+// Tenure returns the length of the range in whole years and months, counting
+// both the start and end months. Present ends at now. It reports false when
+// either end lacks a month or the range is open-ended.
+func (r Range) Tenure(now time.Time) (years, months int, ok bool) {
+	end := r.End
+	if end.Present {
+		end = Date{Year: now.Year(), Month: now.Month()}
+	}
+	if r.Start.Month == 0 || end.Month == 0 {
+		return 0, 0, false
+	}
+
+	total := (end.Year*12 + int(end.Month)) - (r.Start.Year*12 + int(r.Start.Month)) + 1
+	if total <= 0 {
+		return 0, 0, false
+	}
+	return total / 12, total % 12, true
+}
+
+// FormatTenure renders a tenure such as "2 yrs 4 mos", omitting zero parts
+func FormatTenure(years, months int, loc *i18n.Locale) string {
+	var parts []string
+	if years > 0 {
+		unit := loc.YearsUnit
+		if years == 1 {
+			unit = loc.YearUnit
+		}
+		parts = append(parts, strconv.Itoa(years)+" "+unit)
+	}
+	if months > 0 {
+		unit := loc.MonthsUnit
+		if months == 1 {
+			unit = loc.MonthUnit
+		}
+		parts = append(parts, strconv.Itoa(months)+" "+unit)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Notations name the ways a date can be written, by example
+const (
+	NotationISO        = "2020-01"
+	NotationNumeric    = "01/2020"
+	NotationYear       = "2020"
+	NotationShortMonth = "Jan 2020"
+	NotationLongMonth  = "January 2020"
+)
+
+// Notation reports how a date string is written. It returns an empty string
+// for empty, "Present" and unrecognized dates, and for months such as
+// "May 2020" whose short and long names coincide.
+func Notation(s string) string {
+	s = strings.TrimSpace(s)
+	if rest, ok := cutPrefixFold(s, "expected "); ok {
+		s = strings.TrimSpace(rest)
+	}
+
+	switch {
+	case isoRegex.MatchString(s):
+		return NotationISO
+	case numericRegex.MatchString(s):
+		return NotationNumeric
+	case yearRegex.MatchString(s):
+		return NotationYear
+	}
+
+	m := monthRegex.FindStringSubmatch(s)
+	if m == nil {
+		return ""
+	}
+	month, ok := monthNames[strings.ToLower(m[1])]
+	if !ok {
+		return ""
+	}
+	full := month.String()
+	switch {
+	case len(full) <= 4 && strings.EqualFold(m[1], full):
+		return "" // "May", "June" and "July" read as either form
+	case strings.EqualFold(m[1], full):
+		return NotationLongMonth
+	}
+	return NotationShortMonth
+}
@@ -0,0 +1,168 @@
+package dates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Date
+	}{
+		{"", Date{}},
+		{"2020-01", Date{Year: 2020, Month: time.January}},
+		{"2020-1-15", Date{Year: 2020, Month: time.January}},
+		{"03/2021", Date{Year: 2021, Month: time.March}},
+		{"3.2021", Date{Year: 2021, Month: time.March}},
+		{"2019", Date{Year: 2019}},
+		{"Jan 2020", Date{Year: 2020, Month: time.January}},
+		{"Sept. 2018", Date{Year: 2018, Month: time.September}},
+		{"December, 2022", Date{Year: 2022, Month: time.December}},
+		{"Present", Date{Present: true}},
+		{" current ", Date{Present: true}},
+		{"Expected May 2026", Date{Year: 2026, Month: time.May, Expected: true}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"Summer 2019",
+		"2020-00",
+		"00/2020",
+		"2020-13",
+		"13/2020",
+		"1899",
+		"2101-01",
+		"Jan",
+		"last year",
+	} {
+		if d, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %+v, want an error", in, d)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		start, end string
+		current    bool
+		want       Range
+		wantErr    bool
+	}{
+		{start: "2020-01", end: "2021-06", want: Range{Start: Date{Year: 2020, Month: 1}, End: Date{Year: 2021, Month: 6}}},
+		{start: "2020-01", end: "2019-12", wantErr: true},
+		{start: "2020-01", end: "2020", want: Range{Start: Date{Year: 2020, Month: 1}, End: Date{Year: 2020}}},
+		{start: "2020", end: "2020-01", want: Range{Start: Date{Year: 2020}, End: Date{Year: 2020, Month: 1}}},
+		{start: "2020-06", end: "2019", wantErr: true},
+		{start: "2020-01", end: "2019", current: true, want: Range{Start: Date{Year: 2020, Month: 1}, End: Date{Present: true}}},
+		{start: "2020-01", end: "Present", want: Range{Start: Date{Year: 2020, Month: 1}, End: Date{Present: true}}},
+		{start: "Present", end: "2020", wantErr: true},
+		{start: "", end: "2020-05", want: Range{End: Date{Year: 2020, Month: 5}}},
+		{start: "2020-01", end: "Summer 2021", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRange(tt.start, tt.end, tt.current)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRange(%q, %q, %v) error = %v, wantErr %v", tt.start, tt.end, tt.current, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseRange(%q, %q, %v) = %+v, want %+v", tt.start, tt.end, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// In ascending order; each date compares equal only to itself
+	ordered := []Date{
+		{Year: 2019, Month: time.December},
+		{Year: 2020},
+		{Year: 2020, Month: time.January},
+		{Year: 2020, Month: time.December},
+		{Year: 2021},
+		{Present: true},
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			if got, want := a.Compare(b), cmpInt(i, j); got != want {
+				t.Errorf("%+v.Compare(%+v) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestRangeFormat(t *testing.T) {
+	loc := i18n.Default()
+	r := Range{Start: Date{Year: 2020, Month: time.January}, End: Date{Year: 2023, Month: time.March}}
+	tests := []struct {
+		style Style
+		want  string
+	}{
+		{StyleShort, "Jan 2020 – Mar 2023"},
+		{StyleLong, "January 2020 – March 2023"},
+		{StyleNumeric, "01/2020 – 03/2023"},
+		{StyleYear, "2020–2023"},
+	}
+	for _, tt := range tests {
+		if got := r.Format(tt.style, loc); got != tt.want {
+			t.Errorf("Format(%q) = %q, want %q", tt.style, got, tt.want)
+		}
+	}
+
+	same := Range{Start: Date{Year: 2020, Month: time.May}, End: Date{Year: 2020, Month: time.May}}
+	if got := same.Format(StyleShort, loc); got != "May 2020" {
+		t.Errorf("Format of a one-month range = %q, want %q", got, "May 2020")
+	}
+}
+
+func TestTenure(t *testing.T) {
+	now := time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		r             Range
+		years, months int
+		ok            bool
+	}{
+		{Range{Start: Date{Year: 2020, Month: 1}, End: Date{Year: 2021, Month: 12}}, 2, 0, true},
+		{Range{Start: Date{Year: 2022, Month: 3}, End: Date{Year: 2022, Month: 3}}, 0, 1, true},
+		{Range{Start: Date{Year: 2023, Month: 1}, End: Date{Present: true}}, 1, 6, true},
+		{Range{Start: Date{Year: 2020}, End: Date{Year: 2021, Month: 5}}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		years, months, ok := tt.r.Tenure(now)
+		if years != tt.years || months != tt.months || ok != tt.ok {
+			t.Errorf("%+v.Tenure() = %d, %d, %v, want %d, %d, %v", tt.r, years, months, ok, tt.years, tt.months, tt.ok)
+		}
+	}
+}
+
+func TestNotation(t *testing.T) {
+	tests := map[string]string{
+		"2020-01":       NotationISO,
+		"01/2020":       NotationNumeric,
+		"2020":          NotationYear,
+		"Jan 2020":      NotationShortMonth,
+		"January 2020":  NotationLongMonth,
+		"May 2020":      "",
+		"Present":       "",
+		"Summer 2019":   "",
+		"Expected 2026": NotationYear,
+	}
+	for in, want := range tests {
+		if got := Notation(in); got != want {
+			t.Errorf("Notation(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
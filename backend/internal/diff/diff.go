@@ -0,0 +1,608 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// Change kinds
+const (
+	KindAdded   = "added"
+	KindRemoved = "removed"
+	KindChanged = "changed"
+	KindMoved   = "moved"
+)
+
+// settings are the top-level request fields compared as plain values
+var settings = []string{"locale", "dateStyle", "lint", "pdf"}
+
+// Compare returns the structural changes that turn old into new: sections
+// and entries added, removed or moved, fields changed and bullets added,
+// removed, moved or edited. Content is compared as JSON, so every section
+// type, including custom ones, is covered without type-specific code.
+func Compare(old, new *models.ResumeRequest) []models.Change {
+	a, b := toMap(old), toMap(new)
+	d := &differ{changes: []models.Change{}}
+
+	for _, key := range settings {
+		if !reflect.DeepEqual(a[key], b[key]) {
+			d.add(scope{}, models.Change{Kind: KindChanged, Target: "field", Field: key, Before: text(a[key]), After: text(b[key])})
+		}
+	}
+	basicA, _ := a["basicDetails"].(map[string]interface{})
+	basicB, _ := b["basicDetails"].(map[string]interface{})
+	d.fields(scope{}, "basicDetails.", basicA, basicB)
+
+	d.sections(list(a["sections"]), list(b["sections"]))
+	return d.changes
+}
+
+// scope locates the changes being compared
+type scope struct {
+	section string
+	entries []string // Labels of the enclosing entries, outermost first
+}
+
+func (s scope) within(label string) scope {
+	entries := append(append([]string(nil), s.entries...), label)
+	return scope{section: s.section, entries: entries}
+}
+
+type differ struct {
+	changes []models.Change
+}
+
+// add records a change in the given scope and writes its summary
+func (d *differ) add(sc scope, c models.Change) {
+	c.Section = sc.section
+	c.Entry = strings.Join(sc.entries, " › ")
+	c.Summary = summarize(c)
+	d.changes = append(d.changes, c)
+}
+
+// sections matches sections by type and title, then by type alone, so a
+// renamed section still compares as the same section
+func (d *differ) sections(a, b []interface{}) {
+	ma, mb := maps(a), maps(b)
+	key := func(m map[string]interface{}) string {
+		return text(m["type"]) + "\x00" + strings.ToLower(strings.TrimSpace(text(m["title"])))
+	}
+	pairs := match(ma, mb, key, func(m map[string]interface{}) string { return text(m["type"]) })
+
+	d.report(scope{}, "section", "", ma, mb, pairs, sectionLabel)
+	for _, p := range pairs {
+		sc := scope{section: sectionLabel(mb[p[1]])}
+		metaA, metaB := withoutKeys(ma[p[0]], "type", "content"), withoutKeys(mb[p[1]], "type", "content")
+		d.fields(sc, "", metaA, metaB)
+
+		contentA, _ := ma[p[0]]["content"].(map[string]interface{})
+		contentB, _ := mb[p[1]]["content"].(map[string]interface{})
+		d.fields(sc, "", contentA, contentB)
+	}
+}
+
+// fields compares two objects key by key, descending into nested objects
+// and lists
+func (d *differ) fields(sc scope, prefix string, a, b map[string]interface{}) {
+	for _, key := range unionKeys(a, b) {
+		va, vb := a[key], b[key]
+		if idKeys[key] || reflect.DeepEqual(va, vb) {
+			continue
+		}
+
+		la, aIsList := va.([]interface{})
+		lb, bIsList := vb.([]interface{})
+		oa, aIsObject := va.(map[string]interface{})
+		ob, bIsObject := vb.(map[string]interface{})
+		sa, aIsString := va.(string)
+		sb, bIsString := vb.(string)
+		switch {
+		case commaLists[key] && (aIsString || va == nil) && (bIsString || vb == nil):
+			d.strings(sc, prefix+key, splitList(sa), splitList(sb))
+		case (aIsList || va == nil) && (bIsList || vb == nil) && isStrings(la) && isStrings(lb):
+			d.strings(sc, prefix+key, strs(la), strs(lb))
+		case (aIsList || va == nil) && (bIsList || vb == nil) && isObjects(la) && isObjects(lb):
+			d.objects(sc, prefix+key, maps(la), maps(lb))
+		case aIsObject && bIsObject:
+			d.fields(sc, prefix+key+".", oa, ob)
+		default:
+			d.add(sc, models.Change{Kind: KindChanged, Target: "field", Field: prefix + key, Before: text(va), After: text(vb)})
+		}
+	}
+}
+
+// idKeys hold the stable IDs assigned to saved resumes; they identify items
+// rather than describe them, so changes to them are not reported
+var idKeys = map[string]bool{"id": true, "bulletIds": true}
+
+// commaLists are string fields holding comma-separated lists, compared item
+// by item
+var commaLists = map[string]bool{"skills": true, "technologies": true}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// identityFields name an entry, most specific first
+var identityFields = []string{"company", "institution", "organization", "name", "title", "degree", "key", "kind", "url"}
+
+// entryLabel names an entry by its first two identity fields, e.g.
+// "Acme Corp – Engineer"
+func entryLabel(m map[string]interface{}) string {
+	var parts []string
+	for _, field := range identityFields {
+		if v := strings.TrimSpace(text(m[field])); v != "" {
+			parts = append(parts, v)
+			if len(parts) == 2 {
+				break
+			}
+		}
+	}
+	return strings.Join(parts, " – ")
+}
+
+// primaryLabel names an entry by its first identity field, so an entry whose
+// title changed still matches its company
+func primaryLabel(m map[string]interface{}) string {
+	label, _, _ := strings.Cut(entryLabel(m), " – ")
+	return label
+}
+
+// objects compares lists of entries, matched by label rather than position
+func (d *differ) objects(sc scope, field string, a, b []map[string]interface{}) {
+	pairs := match(a, b, entryLabel, primaryLabel)
+	target := noun(field)
+
+	d.report(sc, target, field, a, b, pairs, entryLabel)
+	for _, p := range pairs {
+		label := entryLabel(b[p[1]])
+		if label == "" {
+			label = fmt.Sprintf("%s %d", target, p[1]+1)
+		}
+		d.fields(sc.within(label), "", a[p[0]], b[p[1]])
+	}
+}
+
+// report records the added, removed and reordered items of a matched list
+func (d *differ) report(sc scope, target, field string, a, b []map[string]interface{}, pairs [][2]int, label func(map[string]interface{}) string) {
+	matchedA := make(map[int]bool)
+	matchedB := make(map[int]bool)
+	for _, p := range pairs {
+		matchedA[p[0]] = true
+		matchedB[p[1]] = true
+	}
+
+	for i, m := range a {
+		if !matchedA[i] {
+			d.add(sc, models.Change{Kind: KindRemoved, Target: target, Field: field, Index: i + 1, Before: label(m)})
+		}
+	}
+	for j, m := range b {
+		if !matchedB[j] {
+			d.add(sc, models.Change{Kind: KindAdded, Target: target, Field: field, Index: j + 1, After: label(m)})
+		}
+	}
+	for _, p := range moved(pairs) {
+		d.add(sc, models.Change{Kind: KindMoved, Target: target, Field: field, Index: p[1] + 1, After: label(b[p[1]])})
+	}
+}
+
+// strings compares lists of strings such as bullets. Unchanged items are
+// found with a longest common subsequence; of the rest, identical items are
+// moves and similar ones are edits.
+func (d *differ) strings(sc scope, field string, a, b []string) {
+	target := noun(field)
+	pairs := lcs(len(a), len(b), func(i, j int) bool { return a[i] == b[j] })
+
+	var removed, added []int
+	matchedB := make(map[int]bool)
+	next := 0
+	for i := range a {
+		if next < len(pairs) && pairs[next][0] == i {
+			matchedB[pairs[next][1]] = true
+			next++
+			continue
+		}
+		removed = append(removed, i)
+	}
+	for j := range b {
+		if !matchedB[j] {
+			added = append(added, j)
+		}
+	}
+
+	// Identical text elsewhere in the list is a move
+	var edits [][2]int
+	removed, added, moves := pairUp(removed, added, func(i, j int) bool { return a[i] == b[j] })
+	// Similar text is an edit
+	removed, added, edits = pairUp(removed, added, func(i, j int) bool { return similarity(a[i], b[j]) >= 0.4 })
+
+	for _, p := range moves {
+		d.add(sc, models.Change{Kind: KindMoved, Target: target, Field: field, Index: p[1] + 1, After: b[p[1]]})
+	}
+	for _, p := range edits {
+		d.add(sc, models.Change{Kind: KindChanged, Target: target, Field: field, Index: p[1] + 1, Before: a[p[0]], After: b[p[1]]})
+	}
+	for _, i := range removed {
+		d.add(sc, models.Change{Kind: KindRemoved, Target: target, Field: field, Index: i + 1, Before: a[i]})
+	}
+	for _, j := range added {
+		d.add(sc, models.Change{Kind: KindAdded, Target: target, Field: field, Index: j + 1, After: b[j]})
+	}
+}
+
+// pairUp greedily pairs removed and added indexes that satisfy ok and
+// returns the indexes left over with the pairs
+func pairUp(removed, added []int, ok func(i, j int) bool) ([]int, []int, [][2]int) {
+	var pairs [][2]int
+	usedB := make(map[int]bool)
+	var restA []int
+	for _, i := range removed {
+		found := false
+		for _, j := range added {
+			if !usedB[j] && ok(i, j) {
+				pairs = append(pairs, [2]int{i, j})
+				usedB[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			restA = append(restA, i)
+		}
+	}
+	var restB []int
+	for _, j := range added {
+		if !usedB[j] {
+			restB = append(restB, j)
+		}
+	}
+	return restA, restB, pairs
+}
+
+// match pairs items of a and b with equal stable IDs, then items still
+// unpaired with equal keys, then with equal fallback keys, each time in list
+// order. Items with empty keys are only paired by position among the
+// leftovers.
+func match(a, b []map[string]interface{}, key, fallback func(map[string]interface{}) string) [][2]int {
+	usedA := make(map[int]bool)
+	usedB := make(map[int]bool)
+	var pairs [][2]int
+
+	id := func(m map[string]interface{}) string { return text(m["id"]) }
+	for _, keyOf := range []func(map[string]interface{}) string{id, key, fallback} {
+		for i, ma := range a {
+			k := keyOf(ma)
+			if usedA[i] || k == "" {
+				continue
+			}
+			for j, mb := range b {
+				if !usedB[j] && keyOf(mb) == k {
+					pairs = append(pairs, [2]int{i, j})
+					usedA[i], usedB[j] = true, true
+					break
+				}
+			}
+		}
+	}
+
+	var restA, restB []int
+	for i, m := range a {
+		if !usedA[i] && key(m) == "" {
+			restA = append(restA, i)
+		}
+	}
+	for j, m := range b {
+		if !usedB[j] && key(m) == "" {
+			restB = append(restB, j)
+		}
+	}
+	for k := 0; k < len(restA) && k < len(restB); k++ {
+		pairs = append(pairs, [2]int{restA[k], restB[k]})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+	return pairs
+}
+
+// moved returns the pairs, sorted by old position, that fall outside the
+// longest run keeping its relative order in the new list
+func moved(pairs [][2]int) [][2]int {
+	n := len(pairs)
+	if n < 2 {
+		return nil
+	}
+	length := make([]int, n)
+	prev := make([]int, n)
+	best := 0
+	for i := range pairs {
+		length[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if pairs[j][1] < pairs[i][1] && length[j]+1 > length[i] {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+		if length[i] > length[best] {
+			best = i
+		}
+	}
+
+	inOrder := make(map[int]bool)
+	for i := best; i >= 0; i = prev[i] {
+		inOrder[i] = true
+	}
+	var result [][2]int
+	for i, p := range pairs {
+		if !inOrder[i] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// lcs returns the index pairs of a longest common subsequence
+func lcs(n, m int, eq func(i, j int) bool) [][2]int {
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if eq(i, j) {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+
+	var pairs [][2]int
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case eq(i, j):
+			pairs = append(pairs, [2]int{i, j})
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
+
+// similarity is the share of distinct words two strings have in common
+func similarity(a, b string) float64 {
+	wa, wb := words(a), words(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(wa)+len(wb)-common)
+}
+
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	}) {
+		set[w] = true
+	}
+	return set
+}
+
+// nouns name the items of list fields in summaries
+var nouns = map[string]string{
+	"entries":      "entry",
+	"positions":    "position",
+	"categories":   "category",
+	"bullets":      "bullet",
+	"description":  "bullet",
+	"coursework":   "course",
+	"technologies": "technology",
+	"linkOrder":    "link kind",
+}
+
+// noun returns the item name for a list field such as "entries" or
+// "basicDetails.links"
+func noun(field string) string {
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		field = field[i+1:]
+	}
+	if n, ok := nouns[field]; ok {
+		return n
+	}
+	return strings.TrimSuffix(field, "s")
+}
+
+// sectionLabel names a section by its title or English heading
+func sectionLabel(m map[string]interface{}) string {
+	if title := strings.TrimSpace(text(m["title"])); title != "" {
+		return title
+	}
+	sectionType := text(m["type"])
+	heading := i18n.Default().Heading(sectionType)
+	if heading == "" {
+		return sectionType
+	}
+	parts := strings.Fields(strings.ToLower(heading))
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, " ")
+}
+
+// summarize describes a change in a sentence, e.g. "Edited bullet 2 of
+// Acme Corp – Engineer in Experience"
+func summarize(c models.Change) string {
+	var verb string
+	switch c.Kind {
+	case KindAdded:
+		verb = "Added"
+	case KindRemoved:
+		verb = "Removed"
+	case KindMoved:
+		verb = "Moved"
+	default:
+		verb = "Changed"
+		if c.Target != "field" {
+			verb = "Edited"
+		}
+	}
+
+	var what string
+	switch {
+	case c.Target == "field":
+		what = c.Field
+	case c.Target == "section":
+		what = "section " + c.Before + c.After
+	case c.Index > 0 && (c.Before == "" && c.After == "" || isListItem(c.Target)):
+		what = fmt.Sprintf("%s %d", c.Target, c.Index)
+	default:
+		what = c.Target + " " + firstNonEmpty(c.After, c.Before)
+	}
+
+	where := c.Entry
+	if c.Section != "" {
+		if where != "" {
+			where += " in "
+		}
+		where += c.Section
+	}
+	if c.Target == "section" || where == "" {
+		return verb + " " + what
+	}
+	if c.Kind == KindAdded {
+		return verb + " " + what + " to " + where
+	}
+	if c.Kind == KindRemoved {
+		return verb + " " + what + " from " + where
+	}
+	return verb + " " + what + " of " + where
+}
+
+// isListItem reports whether a target names a plain list item, summarized by
+// position rather than by its text
+func isListItem(target string) bool {
+	switch target {
+	case "entry", "position", "category", "row", "link", "skill", "technology":
+		return false
+	}
+	return true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// toMap converts a request into generic JSON values
+func toMap(req *models.ResumeRequest) map[string]interface{} {
+	m := make(map[string]interface{})
+	if data, err := json.Marshal(req); err == nil {
+		json.Unmarshal(data, &m)
+	}
+	return m
+}
+
+// text renders a JSON value for display: strings as they are, anything else
+// as compact JSON
+func text(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func list(v interface{}) []interface{} {
+	l, _ := v.([]interface{})
+	return l
+}
+
+func isStrings(l []interface{}) bool {
+	for _, v := range l {
+		if _, ok := v.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func isObjects(l []interface{}) bool {
+	for _, v := range l {
+		if _, ok := v.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func strs(l []interface{}) []string {
+	result := make([]string, len(l))
+	for i, v := range l {
+		result[i], _ = v.(string)
+	}
+	return result
+}
+
+func maps(l []interface{}) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, v := range l {
+		if m, ok := v.(map[string]interface{}); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+func withoutKeys(m map[string]interface{}, keys ...string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	for _, k := range keys {
+		delete(result, k)
+	}
+	return result
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]interface{}{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,108 @@
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+func resume(t *testing.T, s string) *models.ResumeRequest {
+	t.Helper()
+	var req models.ResumeRequest
+	if err := json.Unmarshal([]byte(s), &req); err != nil {
+		t.Fatalf("invalid test resume: %v", err)
+	}
+	return &req
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []models.Change // Summaries are not compared
+	}{
+		{
+			name: "unchanged",
+			old:  `{"basicDetails":{"firstName":"Jane"},"sections":[{"type":"experience","content":{"entries":[]}}]}`,
+			new:  `{"basicDetails":{"firstName":"Jane"},"sections":[{"type":"experience","content":{"entries":[]}}]}`,
+			want: []models.Change{},
+		},
+		{
+			name: "settings and basic details",
+			old:  `{"basicDetails":{"email":"a@example.com"}}`,
+			new:  `{"basicDetails":{"email":"b@example.com"},"locale":"fr"}`,
+			want: []models.Change{
+				{Kind: KindChanged, Target: "field", Field: "locale", After: "fr"},
+				{Kind: KindChanged, Target: "field", Field: "basicDetails.email", Before: "a@example.com", After: "b@example.com"},
+			},
+		},
+		{
+			name: "sections added, removed and moved",
+			old:  `{"sections":[{"type":"profile_summary","content":{}},{"type":"experience","content":{}},{"type":"education","content":{}}]}`,
+			new:  `{"sections":[{"type":"education","content":{}},{"type":"experience","content":{}},{"type":"awards","content":{}}]}`,
+			want: []models.Change{
+				{Kind: KindRemoved, Target: "section", Index: 1, Before: "Objective"},
+				{Kind: KindAdded, Target: "section", Index: 3, After: "Awards"},
+				{Kind: KindMoved, Target: "section", Index: 1, After: "Education"},
+			},
+		},
+		{
+			name: "renamed section matches by type",
+			old:  `{"sections":[{"type":"custom","title":"Talks","content":{"layout":"bullets"}}]}`,
+			new:  `{"sections":[{"type":"custom","title":"Speaking","content":{"layout":"bullets"}}]}`,
+			want: []models.Change{
+				{Kind: KindChanged, Target: "field", Section: "Speaking", Field: "title", Before: "Talks", After: "Speaking"},
+			},
+		},
+		{
+			name: "entry fields and bullets",
+			old: `{"sections":[{"type":"experience","content":{"entries":[
+				{"company":"Acme","title":"Engineer","endDate":"2022-01","bullets":["Built the billing service","Cut latency by 40%","Mentored interns"]}]}}]}`,
+			new: `{"sections":[{"type":"experience","content":{"entries":[
+				{"company":"Acme","title":"Senior Engineer","endDate":"2023-06","bullets":["Mentored interns","Built the billing service","Cut p99 latency by 40%","Led the migration"]}]}}]}`,
+			want: []models.Change{
+				{Kind: KindMoved, Target: "bullet", Section: "Experience", Entry: "Acme – Senior Engineer", Field: "bullets", Index: 2, After: "Built the billing service"},
+				{Kind: KindChanged, Target: "bullet", Section: "Experience", Entry: "Acme – Senior Engineer", Field: "bullets", Index: 3, Before: "Cut latency by 40%", After: "Cut p99 latency by 40%"},
+				{Kind: KindAdded, Target: "bullet", Section: "Experience", Entry: "Acme – Senior Engineer", Field: "bullets", Index: 4, After: "Led the migration"},
+				{Kind: KindChanged, Target: "field", Section: "Experience", Entry: "Acme – Senior Engineer", Field: "endDate", Before: "2022-01", After: "2023-06"},
+				{Kind: KindChanged, Target: "field", Section: "Experience", Entry: "Acme – Senior Engineer", Field: "title", Before: "Engineer", After: "Senior Engineer"},
+			},
+		},
+		{
+			name: "entries matched by ID",
+			old: `{"sections":[{"type":"experience","content":{"entries":[
+				{"id":"e-1","company":"Acme","title":"Engineer"},{"id":"e-2","company":"Globex","title":"Intern"}]}}]}`,
+			new: `{"sections":[{"type":"experience","content":{"entries":[
+				{"id":"e-2","company":"Globex","title":"Intern"},{"id":"e-1","company":"Acme Inc","title":"Engineer"}]}}]}`,
+			want: []models.Change{
+				{Kind: KindMoved, Target: "entry", Section: "Experience", Field: "entries", Index: 1, After: "Globex – Intern"},
+				{Kind: KindChanged, Target: "field", Section: "Experience", Entry: "Acme Inc – Engineer", Field: "company", Before: "Acme", After: "Acme Inc"},
+			},
+		},
+		{
+			name: "comma-separated skills",
+			old:  `{"sections":[{"type":"tech_skills","content":{"categories":[{"name":"Languages","skills":"Go, Python"}]}}]}`,
+			new:  `{"sections":[{"type":"tech_skills","content":{"categories":[{"name":"Languages","skills":"Go, Python, Rust"}]}}]}`,
+			want: []models.Change{
+				{Kind: KindAdded, Target: "skill", Section: "Skills", Entry: "Languages", Field: "skills", Index: 3, After: "Rust"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare(resume(t, tt.old), resume(t, tt.new))
+			for i := range got {
+				if got[i].Summary == "" {
+					t.Errorf("change %d has no summary", i+1)
+				}
+				got[i].Summary = ""
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compare =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
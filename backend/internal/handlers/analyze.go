@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sahil/ats-resume-maker/backend/internal/analysis"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// maxJobDescriptionLength bounds the pasted job description, in bytes
+const maxJobDescriptionLength = 50000
+
+// AnalyzeResume handles the job description keyword match request
+func AnalyzeResume(c *gin.Context) {
+	var request models.AnalyzeRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: []string{err.Error()},
+		})
+		return
+	}
+
+	var errs []string
+	if strings.TrimSpace(request.JobDescription) == "" {
+		errs = append(errs, "Job description is required")
+	}
+	if len(request.JobDescription) > maxJobDescriptionLength {
+		errs = append(errs, fmt.Sprintf("Job description must be at most %d characters", maxJobDescriptionLength))
+	}
+	if len(request.Sections) == 0 {
+		errs = append(errs, "At least one section is required")
+	}
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: errs,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AnalyzeResponse{
+		Success: true,
+		Report:  analysis.Analyze(&request.ResumeRequest, request.JobDescription),
+	})
+}
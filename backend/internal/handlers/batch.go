@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+const (
+	maxBatchBytes = 32 << 20 // Bounds the request body
+	maxBatchItems = 500      // For batch jobs
+	// maxSyncBatchItems keeps a batch compiled within the request well
+	// inside proxy timeouts; larger batches go through the job API
+	maxSyncBatchItems = 20
+)
+
+// batchConcurrency is the most compilations a batch runs at once
+var batchConcurrency = envInt("BATCH_CONCURRENCY", 4)
+
+// envInt reads a positive integer from the environment
+func envInt(key string, defaultVal int) int {
+	n, err := strconv.Atoi(getEnvOrDefault(key, strconv.Itoa(defaultVal)))
+	if err != nil || n < 1 {
+		return defaultVal
+	}
+	return n
+}
+
+// batchItem is one resume of a batch with the PDF it compiled to
+type batchItem struct {
+	request *models.ResumeRequest
+	status  models.BatchItemStatus
+	pdf     []byte
+}
+
+// CompileBatch handles compiling many resumes at once, responding with a ZIP
+// archive of the PDFs and a manifest of every item's outcome. Items that
+// fail do not fail the batch.
+func CompileBatch(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBytes)
+
+	var request models.BatchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: []string{err.Error()},
+		})
+		return
+	}
+	if errs := validateBatch(&request, maxSyncBatchItems); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: errs,
+		})
+		return
+	}
+
+	items := expandBatch(&request)
+	manifest := runBatch(c.Request.Context(), items, request.Concurrency, nil)
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="resumes.zip"`)
+	c.Status(http.StatusOK)
+	if err := writeBatchArchive(c.Writer, items, manifest); err != nil {
+		// The status line is already sent; dropping the connection is all
+		// that is left
+		c.Error(err)
+		c.Abort()
+	}
+}
+
+// validateBatch checks the shape of a batch holding at most limit items; the
+// resumes themselves are validated per item
+func validateBatch(req *models.BatchRequest, limit int) []string {
+	var errs []string
+
+	count := len(req.Resumes)
+	switch {
+	case req.Resume != nil && len(req.Resumes) > 0:
+		errs = append(errs, "Send either resumes or a resume with overrides, not both")
+	case req.Resume != nil:
+		count = len(req.Overrides)
+		if count == 0 {
+			errs = append(errs, "At least one override is required with a single resume")
+		}
+	case len(req.Overrides) > 0:
+		errs = append(errs, "Overrides need a resume to apply to")
+	}
+	if count == 0 && len(errs) == 0 {
+		errs = append(errs, "At least one resume is required")
+	}
+	if count > limit {
+		msg := fmt.Sprintf("A batch can hold at most %d items, got %d", limit, count)
+		if limit < maxBatchItems {
+			msg += fmt.Sprintf("; submit batches of up to %d items as a job to /api/jobs", maxBatchItems)
+		}
+		errs = append(errs, msg)
+	}
+
+	if req.Concurrency < 0 {
+		errs = append(errs, "Concurrency cannot be negative")
+	}
+	for i, o := range req.Overrides {
+		if o.PDF != nil {
+			for _, e := range validatePDFOptions(o.PDF) {
+				errs = append(errs, fmt.Sprintf("Override %d: %s", i, e))
+			}
+		}
+	}
+
+	return errs
+}
+
+// expandBatch lists the resumes of a batch, applying each override to a copy
+// of the shared resume
+func expandBatch(req *models.BatchRequest) []*batchItem {
+	var items []*batchItem
+	if req.Resume == nil {
+		for i := range req.Resumes {
+			items = append(items, &batchItem{request: &req.Resumes[i]})
+		}
+	} else {
+		for _, o := range req.Overrides {
+			r := *req.Resume
+			if o.Locale != "" {
+				r.Locale = o.Locale
+			}
+			if o.DateStyle != "" {
+				r.DateStyle = o.DateStyle
+			}
+			if o.PDF != nil {
+				r.PDF = o.PDF
+			}
+			items = append(items, &batchItem{request: &r})
+		}
+	}
+
+	for i, item := range items {
+		bd := item.request.BasicDetails
+		item.status = models.BatchItemStatus{
+			Index:  i,
+			Name:   strings.TrimSpace(bd.FirstName + " " + bd.LastName),
+			Locale: item.request.Locale,
+		}
+	}
+	return items
+}
+
+// runBatch compiles the items with at most concurrency compilations at once,
+// capped by batchConcurrency. Items not finished when ctx is done are marked
+// canceled. done, when set, is called as each item finishes; calls may come
+// from several goroutines at once.
+func runBatch(ctx context.Context, items []*batchItem, concurrency int, done func(*batchItem)) *models.BatchManifest {
+	if concurrency < 1 || concurrency > batchConcurrency {
+		concurrency = batchConcurrency
+	}
+
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			item.status.Status = models.BatchStatusCanceled
+			item.status.Errors = []string{ctx.Err().Error()}
+			if done != nil {
+				done(item)
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(item *batchItem) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			compileBatchItem(ctx, item)
+			if done != nil {
+				done(item)
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	manifest := &models.BatchManifest{Total: len(items), Items: make([]models.BatchItemStatus, len(items))}
+	for i, item := range items {
+		manifest.Items[i] = item.status
+		if item.status.Status == models.BatchStatusCompiled {
+			manifest.Compiled++
+		} else {
+			manifest.Failed++
+		}
+	}
+	return manifest
+}
+
+// compileBatchItem validates and compiles one item, recording the outcome in
+// its status
+func compileBatchItem(ctx context.Context, item *batchItem) {
+	if errs := validateRequest(item.request); len(errs) > 0 {
+		item.status.Status = models.BatchStatusInvalid
+		item.status.Errors = errs
+		return
+	}
+
+	result, err := compiler.CompileResumeContext(ctx, item.request, nil)
+	if ctx.Err() != nil {
+		item.status.Status = models.BatchStatusCanceled
+		item.status.Errors = []string{ctx.Err().Error()}
+		return
+	}
+	if err != nil {
+		item.status.Status = models.BatchStatusFailed
+		item.status.Errors = []string{err.Error()}
+		return
+	}
+	item.pdf = result.PDF
+	item.status.Status = models.BatchStatusCompiled
+	// The index keeps files apart when several items share a name
+	item.status.File = fmt.Sprintf("%03d_%s", item.status.Index, result.PDFName)
+}
+
+// writeBatchArchive writes the compiled PDFs and manifest.json as a ZIP
+// archive
+func writeBatchArchive(w io.Writer, items []*batchItem, manifest *models.BatchManifest) error {
+	zw := zip.NewWriter(w)
+	for _, item := range items {
+		if item.pdf == nil {
+			continue
+		}
+		f, err := zw.Create(item.status.File)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(item.pdf); err != nil {
+			return err
+		}
+	}
+
+	f, err := zw.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return err
+	}
+	return zw.Close()
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// Limits on cover letter content
+const (
+	maxParagraphs       = 20
+	maxCoverLetterField = 200
+)
+
+// CompileCoverLetter handles the cover letter compilation request
+func CompileCoverLetter(c *gin.Context) {
+	var request models.CoverLetterRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: []string{err.Error()},
+		})
+		return
+	}
+
+	if errs := validateCoverLetter(&request); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: errs,
+		})
+		return
+	}
+
+	result, err := compiler.CompileCoverLetter(&request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   "LaTeX compilation failed",
+			Details: []string{err.Error()},
+		})
+		return
+	}
+
+	pdfBase64, ok := readPDF(c, result.PDFName)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success:   true,
+		Message:   "Cover letter compiled successfully",
+		PDFUrl:    "/api/download/" + result.PDFName,
+		PDFBase64: pdfBase64,
+		Tagged:    result.Tagged,
+	})
+}
+
+func validateCoverLetter(req *models.CoverLetterRequest) []string {
+	errors := validateBasicDetails(&req.BasicDetails)
+
+	nonBlank := 0
+	for _, p := range req.Paragraphs {
+		if strings.TrimSpace(p) != "" {
+			nonBlank++
+		}
+	}
+	if nonBlank == 0 {
+		errors = append(errors, "At least one paragraph is required")
+	}
+	if len(req.Paragraphs) > maxParagraphs {
+		errors = append(errors, fmt.Sprintf("A cover letter can have at most %d paragraphs", maxParagraphs))
+	}
+
+	fields := []struct{ name, value string }{
+		{"Recipient name", req.Recipient.Name},
+		{"Recipient title", req.Recipient.Title},
+		{"Company", req.Company},
+		{"Date", req.Date},
+		{"Salutation", req.Salutation},
+		{"Closing", req.Closing},
+	}
+	for i, line := range req.Recipient.Address {
+		fields = append(fields, struct{ name, value string }{fmt.Sprintf("Address line %d", i+1), line})
+	}
+	for _, f := range fields {
+		if len(f.value) > maxCoverLetterField {
+			errors = append(errors, fmt.Sprintf("%s must be at most %d characters", f.name, maxCoverLetterField))
+		}
+	}
+
+	if _, ok := i18n.Lookup(req.Locale); !ok {
+		errors = append(errors, fmt.Sprintf("Unsupported locale %q", req.Locale))
+	}
+	errors = append(errors, validatePDFOptions(req.PDF)...)
+
+	return errors
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sahil/ats-resume-maker/backend/internal/importer"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// maxImportBytes bounds an uploaded file
+const maxImportBytes = 10 << 20
+
+// importHints explain the importer errors users can do something about
+var importHints = map[error]string{
+	importer.ErrNoText:      "Scanned PDFs need to be run through OCR before they can be imported",
+	importer.ErrNotLinkedIn: "Upload the ZIP file from LinkedIn's \"Get a copy of your data\" settings without unpacking it",
+	importer.ErrNotResume:   "Resumes written with another document class can be imported from their PDF instead",
+}
+
+// ImportPDF handles reading a resume from an uploaded PDF. The result is a
+// draft with a confidence score per field, for the user to review.
+func ImportPDF(c *gin.Context) {
+	importUpload(c, importer.FromPDF, "Could not read the PDF")
+}
+
+// ImportLinkedIn handles reading a resume from the ZIP archive of a
+// LinkedIn data export
+func ImportLinkedIn(c *gin.Context) {
+	importUpload(c, importer.FromLinkedIn, "Could not read the archive")
+}
+
+// ImportLaTeX handles reading a resume from an uploaded .tex file written
+// with resume.cls
+func ImportLaTeX(c *gin.Context) {
+	importUpload(c, importer.FromLaTeX, "Could not read the LaTeX source")
+}
+
+// importUpload runs an importer on the uploaded file and writes the result
+func importUpload(c *gin.Context, read func([]byte) (*models.ImportResult, error), failure string) {
+	data, ok := readUpload(c)
+	if !ok {
+		return
+	}
+
+	result, err := read(data)
+	if err != nil {
+		details := []string{err.Error()}
+		for target, hint := range importHints {
+			if errors.Is(err, target) {
+				details = append(details, hint)
+			}
+		}
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   failure,
+			Details: details,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ImportResponse{Success: true, Import: result})
+}
+
+// readUpload reads the multipart "file" field, writing the error response on
+// failure
+func readUpload(c *gin.Context) ([]byte, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: []string{"Upload the file as the multipart form field \"file\" (at most 10 MB): " + err.Error()},
+		})
+		return nil, false
+	}
+
+	f, err := header.Open()
+	if err == nil {
+		defer f.Close()
+		var data []byte
+		if data, err = io.ReadAll(f); err == nil {
+			return data, true
+		}
+	}
+	c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		Success: false,
+		Error:   "Invalid request format",
+		Details: []string{err.Error()},
+	})
+	return nil, false
+}
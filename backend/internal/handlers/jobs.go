@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sahil/ats-resume-maker/backend/internal/jobs"
+	"github.com/sahil/ats-resume-maker/backend/internal/latex"
+	"github.com/sahil/ats-resume-maker/backend/internal/lint"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+const (
+	maxJobs       = 100              // Jobs queued or running at once, and unexpired finished jobs held
+	maxJobFiles   = 256 << 20        // Bytes of files held for unexpired finished jobs
+	jobsKeepAlive = 15 * time.Second // Comment interval that keeps idle event streams open behind proxies
+)
+
+// jobQueue runs the compilations submitted to /api/jobs
+var jobQueue = jobs.NewManager(envInt("JOB_WORKERS", 2), maxJobs, maxJobFiles, envDuration("JOB_TTL", time.Hour))
+
+// envDuration reads a positive duration such as "30m" from the environment
+func envDuration(key string, defaultVal time.Duration) time.Duration {
+	d, err := time.ParseDuration(getEnvOrDefault(key, defaultVal.String()))
+	if err != nil || d <= 0 {
+		return defaultVal
+	}
+	return d
+}
+
+// CreateJob handles submitting a compilation to run in the background. It
+// responds with the queued job at once; the job is then polled, watched
+// through its event stream or canceled.
+func CreateJob(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBytes)
+
+	var request models.JobRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: []string{err.Error()},
+		})
+		return
+	}
+
+	run, errs := jobFunc(&request)
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: errs,
+		})
+		return
+	}
+
+	job, err := jobQueue.Submit(request.Type, run)
+	if err != nil {
+		jobError(c, err)
+		return
+	}
+
+	c.Header("Location", "/api/jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, models.JobResponse{Success: true, Job: jobView(job)})
+}
+
+// GetJob handles loading the status of a job, with its result once done
+func GetJob(c *gin.Context) {
+	job, err := jobQueue.Get(c.Param("id"))
+	if err != nil {
+		jobError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.JobResponse{Success: true, Job: jobView(job)})
+}
+
+// CancelJob handles stopping a queued or running job
+func CancelJob(c *gin.Context) {
+	job, err := jobQueue.Cancel(c.Param("id"))
+	if err != nil {
+		jobError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.JobResponse{Success: true, Job: jobView(job)})
+}
+
+// JobEvents handles streaming the state of a job as server-sent events. Each
+// change is sent as a "message" event holding the job; the stream ends after
+// the job finishes.
+func JobEvents(c *gin.Context) {
+	id := c.Param("id")
+	job, changed, err := jobQueue.Watch(id)
+	if err != nil {
+		jobError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	keepAlive := time.NewTicker(jobsKeepAlive)
+	defer keepAlive.Stop()
+
+	c.SSEvent("message", jobView(job))
+	c.Writer.Flush()
+	for !jobs.Finished(job) {
+		select {
+		case <-changed:
+			if job, changed, err = jobQueue.Watch(id); err != nil {
+				return // Expired while watched
+			}
+			c.SSEvent("message", jobView(job))
+		case <-keepAlive.C:
+			c.Writer.WriteString(": keep-alive\n\n")
+		case <-c.Request.Context().Done():
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+// DownloadJob handles downloading the file a job produced
+func DownloadJob(c *gin.Context) {
+	job, file, err := jobQueue.File(c.Param("id"))
+	if err != nil {
+		jobError(c, err)
+		return
+	}
+	if file == nil {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Success: false,
+			Error:   "Job has no result",
+			Details: []string{fmt.Sprintf("The job is %s", job.Status)},
+		})
+		return
+	}
+
+	contentType := "application/pdf"
+	if filepath.Ext(job.Result.FileName) == ".zip" {
+		contentType = "application/zip"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", job.Result.FileName))
+	c.Data(http.StatusOK, contentType, file)
+}
+
+// jobFunc validates a job request and returns the function that runs it
+func jobFunc(req *models.JobRequest) (jobs.Func, []string) {
+	switch req.Type {
+	case models.JobTypeResume:
+		if req.Resume == nil {
+			return nil, []string{"A resume job needs a resume"}
+		}
+		if errs := validateRequest(req.Resume); len(errs) > 0 {
+			return nil, errs
+		}
+		return resumeJob(req.Resume), nil
+	case models.JobTypeCoverLetter:
+		if req.CoverLetter == nil {
+			return nil, []string{"A cover letter job needs a coverLetter"}
+		}
+		if errs := validateCoverLetter(req.CoverLetter); len(errs) > 0 {
+			return nil, errs
+		}
+		return coverLetterJob(req.CoverLetter), nil
+	case models.JobTypeBatch:
+		if req.Batch == nil {
+			return nil, []string{"A batch job needs a batch"}
+		}
+		if errs := validateBatch(req.Batch, maxBatchItems); len(errs) > 0 {
+			return nil, errs
+		}
+		return batchJob(req.Batch), nil
+	}
+	return nil, []string{fmt.Sprintf("Type must be %q, %q or %q", models.JobTypeResume, models.JobTypeCoverLetter, models.JobTypeBatch)}
+}
+
+func resumeJob(req *models.ResumeRequest) jobs.Func {
+	return func(ctx context.Context, p *jobs.Progress) (*jobs.Output, error) {
+		result, err := compiler.CompileResumeContext(ctx, req, latex.Progress(p.Stage))
+		if err != nil {
+			return nil, fmt.Errorf("LaTeX compilation failed: %w", err)
+		}
+
+		out := &models.JobResult{
+			FileName:     result.PDFName,
+			Metrics:      result.Metrics,
+			Parseability: result.Parseability,
+			Tagged:       result.Tagged,
+		}
+		if req.Lint != nil {
+			out.Lint = lint.Run(req, req.Lint)
+		}
+		return &jobs.Output{File: result.PDF, Result: out}, nil
+	}
+}
+
+func coverLetterJob(req *models.CoverLetterRequest) jobs.Func {
+	return func(ctx context.Context, p *jobs.Progress) (*jobs.Output, error) {
+		result, err := compiler.CompileCoverLetterContext(ctx, req, latex.Progress(p.Stage))
+		if err != nil {
+			return nil, fmt.Errorf("LaTeX compilation failed: %w", err)
+		}
+		return &jobs.Output{
+			File:   result.PDF,
+			Result: &models.JobResult{FileName: result.PDFName, Tagged: result.Tagged},
+		}, nil
+	}
+}
+
+// batchJob compiles a batch into the same archive as /api/compile-batch,
+// counting finished items as the job's progress
+func batchJob(req *models.BatchRequest) jobs.Func {
+	return func(ctx context.Context, p *jobs.Progress) (*jobs.Output, error) {
+		items := expandBatch(req)
+		p.Items(len(items))
+		p.Stage("compiling")
+		manifest := runBatch(ctx, items, req.Concurrency, func(*batchItem) { p.Complete() })
+
+		var archive bytes.Buffer
+		if err := writeBatchArchive(&archive, items, manifest); err != nil {
+			return nil, fmt.Errorf("failed to write archive: %w", err)
+		}
+		return &jobs.Output{
+			File:   archive.Bytes(),
+			Result: &models.JobResult{FileName: "resumes.zip", Manifest: manifest},
+		}, nil
+	}
+}
+
+// jobView adds the download URL to a finished job
+func jobView(job *models.Job) *models.Job {
+	if job.Result != nil {
+		result := *job.Result
+		result.DownloadURL = "/api/jobs/" + job.ID + "/download"
+		job.Result = &result
+	}
+	return job
+}
+
+// jobError writes the response for a job manager error
+func jobError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   "Job not found",
+			Details: []string{"Finished jobs are discarded once they expire, or earlier to make room for newer ones"},
+		})
+	case errors.Is(err, jobs.ErrFinished):
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Success: false,
+			Error:   "Job already finished",
+		})
+	case errors.Is(err, jobs.ErrFull):
+		c.Header("Retry-After", "60")
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Success: false,
+			Error:   "Too many jobs",
+			Details: []string{"Try again once queued and running jobs have finished and older results have expired"},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   "Could not start the job",
+			Details: []string{err.Error()},
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sahil/ats-resume-maker/backend/internal/lint"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// LintResume handles the resume lint request. Drafts are linted as they are,
+// so only the lint config itself is validated.
+func LintResume(c *gin.Context) {
+	var request models.ResumeRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: []string{err.Error()},
+		})
+		return
+	}
+
+	if errs := lint.ValidateConfig(request.Lint); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: errs,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.LintResponse{
+		Success: true,
+		Issues:  lint.Run(&request, request.Lint),
+	})
+}
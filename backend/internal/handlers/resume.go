@@ -2,13 +2,19 @@ package handlers
 
 import (
 	"encoding/base64"
+	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sahil/ats-resume-maker/backend/internal/dates"
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
 	"github.com/sahil/ats-resume-maker/backend/internal/latex"
+	"github.com/sahil/ats-resume-maker/backend/internal/links"
+	"github.com/sahil/ats-resume-maker/backend/internal/lint"
 	"github.com/sahil/ats-resume-maker/backend/internal/models"
+	"github.com/sahil/ats-resume-maker/backend/internal/phone"
 )
 
 var compiler *latex.Compiler
@@ -18,6 +24,8 @@ func init() {
 	templateDir := getEnvOrDefault("TEMPLATE_DIR", "./templates")
 	outputDir := getEnvOrDefault("OUTPUT_DIR", "./output")
 	compiler = latex.NewCompiler(templateDir, outputDir)
+	// Single, batch and job compilations share one limit on LaTeX processes
+	compiler.SetMaxConcurrent(envInt("COMPILE_CONCURRENCY", 4))
 }
 
 func getEnvOrDefault(key, defaultVal string) string {
@@ -40,8 +48,14 @@ func CompileResume(c *gin.Context) {
 		return
 	}
 
+	compileAndRespond(c, &request)
+}
+
+// compileAndRespond validates and compiles a resume and writes the compile
+// response
+func compileAndRespond(c *gin.Context, request *models.ResumeRequest) {
 	// Validate required fields
-	if errs := validateRequest(&request); len(errs) > 0 {
+	if errs := validateRequest(request); len(errs) > 0 {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
 			Error:   "Validation failed",
@@ -51,7 +65,7 @@ func CompileResume(c *gin.Context) {
 	}
 
 	// Compile the resume
-	pdfName, err := compiler.CompileResume(&request)
+	result, err := compiler.CompileResume(request)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
@@ -61,27 +75,41 @@ func CompileResume(c *gin.Context) {
 		return
 	}
 
-	// Read PDF and encode as base64
+	pdfBase64, ok := readPDF(c, result.PDFName)
+	if !ok {
+		return
+	}
+
+	response := models.SuccessResponse{
+		Success:      true,
+		Message:      "Resume compiled successfully",
+		PDFUrl:       "/api/download/" + result.PDFName,
+		PDFBase64:    pdfBase64,
+		Metrics:      result.Metrics,
+		Parseability: result.Parseability,
+		Tagged:       result.Tagged,
+	}
+	if request.Lint != nil {
+		response.Lint = lint.Run(request, request.Lint)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// readPDF reads a compiled PDF and encodes it as base64, writing the error
+// response on failure
+func readPDF(c *gin.Context, pdfName string) (string, bool) {
 	outputDir := getEnvOrDefault("OUTPUT_DIR", "./output")
-	pdfPath := filepath.Join(outputDir, pdfName)
-	pdfContent, err := os.ReadFile(pdfPath)
+	pdfContent, err := os.ReadFile(filepath.Join(outputDir, pdfName))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
 			Error:   "Failed to read generated PDF",
 			Details: []string{err.Error()},
 		})
-		return
+		return "", false
 	}
-
-	pdfBase64 := base64.StdEncoding.EncodeToString(pdfContent)
-
-	c.JSON(http.StatusOK, models.SuccessResponse{
-		Success:   true,
-		Message:   "Resume compiled successfully",
-		PDFUrl:    "/api/download/" + pdfName,
-		PDFBase64: pdfBase64,
-	})
+	return base64.StdEncoding.EncodeToString(pdfContent), true
 }
 
 // DownloadPDF handles PDF file downloads
@@ -109,23 +137,174 @@ func DownloadPDF(c *gin.Context) {
 }
 
 func validateRequest(req *models.ResumeRequest) []string {
+	errors := validateBasicDetails(&req.BasicDetails)
+
+	if _, ok := i18n.Lookup(req.Locale); !ok {
+		errors = append(errors, fmt.Sprintf("Unsupported locale %q", req.Locale))
+	}
+	if _, ok := dates.ParseStyle(req.DateStyle); !ok {
+		errors = append(errors, fmt.Sprintf("Unsupported date style %q", req.DateStyle))
+	}
+	errors = append(errors, lint.ValidateConfig(req.Lint)...)
+	errors = append(errors, validateDates(req.Sections)...)
+	errors = append(errors, validateSectionContent(req.Sections)...)
+	for i, section := range req.Sections {
+		if !sectionTypes[section.Type] {
+			errors = append(errors, fmt.Sprintf("Section %d: unsupported type %q", i+1, section.Type))
+		}
+		switch section.Sort {
+		case "", latex.SortNone, latex.SortReverseChronological:
+		default:
+			errors = append(errors, fmt.Sprintf("Section %d: unsupported sort mode %q", i+1, section.Sort))
+		}
+	}
+	errors = append(errors, validatePDFOptions(req.PDF)...)
+
+	return errors
+}
+
+// validateBasicDetails checks the header shared by resumes and cover letters
+func validateBasicDetails(bd *models.BasicDetails) []string {
 	var errors []string
 
-	if req.BasicDetails.FirstName == "" {
+	if bd.FirstName == "" {
 		errors = append(errors, "First name is required")
 	}
-	if req.BasicDetails.LastName == "" {
+	if bd.LastName == "" {
 		errors = append(errors, "Last name is required")
 	}
-	if req.BasicDetails.Email == "" {
+	if bd.Email == "" {
 		errors = append(errors, "Email is required")
 	}
-	if req.BasicDetails.City == "" {
+	if bd.Phone != "" {
+		if _, err := phone.Parse(bd.Phone); err != nil {
+			errors = append(errors, "Phone must be in international format, e.g. +14165551234")
+		}
+	}
+	if bd.City == "" {
 		errors = append(errors, "City is required")
 	}
-	if req.BasicDetails.Province == "" {
+	if bd.Province == "" {
 		errors = append(errors, "Province is required")
 	}
 
+	for i, link := range bd.Links {
+		if _, err := links.Normalize(link.Kind, link.Label, link.URL); err != nil {
+			errors = append(errors, fmt.Sprintf("Link %d: %s", i+1, err.Error()))
+		}
+	}
+	switch bd.LinkLayout {
+	case "", "inline", "stacked":
+	default:
+		errors = append(errors, "Link layout must be \"inline\" or \"stacked\"")
+	}
+
 	return errors
 }
+
+// Limits on the metadata written into the PDF
+const (
+	maxMetadataLength = 200
+	maxPDFKeywords    = 40
+)
+
+// validatePDFOptions checks the metadata overrides
+func validatePDFOptions(opts *models.PDFOptions) []string {
+	if opts == nil {
+		return nil
+	}
+
+	var errors []string
+	if len(opts.Title) > maxMetadataLength {
+		errors = append(errors, fmt.Sprintf("PDF title must be at most %d characters", maxMetadataLength))
+	}
+	if len(opts.Subject) > maxMetadataLength {
+		errors = append(errors, fmt.Sprintf("PDF subject must be at most %d characters", maxMetadataLength))
+	}
+	if len(opts.Keywords) > maxPDFKeywords {
+		errors = append(errors, fmt.Sprintf("PDF keywords are limited to %d", maxPDFKeywords))
+	}
+	for i, keyword := range opts.Keywords {
+		if len(keyword) > maxMetadataLength {
+			errors = append(errors, fmt.Sprintf("PDF keyword %d must be at most %d characters", i+1, maxMetadataLength))
+		}
+	}
+	return errors
+}
+
+// sectionTypes lists the section types the compiler renders
+var sectionTypes = map[string]bool{
+	"profile_summary": true, "tech_skills": true, "experience": true,
+	"projects": true, "volunteer": true, "education": true,
+	"certifications": true, "awards": true, "publications": true,
+	"languages": true, "interests": true, "custom": true,
+}
+
+// sectionLabels names the dated section types in validation messages
+var sectionLabels = map[string]string{
+	"experience": "Experience",
+	"volunteer":  "Volunteer",
+	"education":  "Education",
+	"projects":   "Projects",
+}
+
+// validateDates checks that entry date ranges are ordered. Free-form dates
+// such as "Summer 2019" are accepted and rendered as given.
+func validateDates(sections []models.Section) []string {
+	var errors []string
+
+	for _, section := range sections {
+		label, ok := sectionLabels[section.Type]
+		if !ok {
+			continue
+		}
+		data, _ := section.Content.(map[string]interface{})
+		entries, _ := data["entries"].([]interface{})
+
+		for i, entry := range entries {
+			e, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			// Grouped experience entries carry dates on each position
+			if positions, _ := e["positions"].([]interface{}); len(positions) > 0 {
+				for j, position := range positions {
+					p, _ := position.(map[string]interface{})
+					startDate, _ := p["startDate"].(string)
+					endDate, _ := p["endDate"].(string)
+					current, _ := p["current"].(bool)
+					if err := checkRange(startDate, endDate, current); err != nil {
+						errors = append(errors, fmt.Sprintf("%s entry %d, position %d: %s", label, i+1, j+1, err.Error()))
+					}
+				}
+				continue
+			}
+
+			if section.Type == "projects" {
+				continue // A single date, rendered as given when free-form
+			}
+			startDate, _ := e["startDate"].(string)
+			endDate, _ := e["endDate"].(string)
+			current, _ := e["current"].(bool)
+			if err := checkRange(startDate, endDate, current); err != nil {
+				errors = append(errors, fmt.Sprintf("%s entry %d: %s", label, i+1, err.Error()))
+			}
+		}
+	}
+
+	return errors
+}
+
+// checkRange reports a range whose end precedes its start or that starts at
+// "Present". Ranges with a free-form end are not checked.
+func checkRange(start, end string, current bool) error {
+	if _, err := dates.Parse(start); err != nil {
+		return nil
+	}
+	if _, err := dates.Parse(end); err != nil && !current {
+		return nil
+	}
+	_, err := dates.ParseRange(start, end, current)
+	return err
+}
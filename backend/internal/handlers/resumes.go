@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+	"github.com/sahil/ats-resume-maker/backend/internal/store"
+	"github.com/sahil/ats-resume-maker/backend/internal/variant"
+)
+
+// maxSavedResumeBytes bounds the body of a saved resume
+const maxSavedResumeBytes = 1 << 20
+
+// maxRevisionNoteLength bounds the optional "note" query parameter that
+// labels a revision
+const maxRevisionNoteLength = 200
+
+var resumes store.Store
+
+// SetStore sets the store used by the saved resume endpoints
+func SetStore(s store.Store) {
+	resumes = s
+}
+
+// CreateResume handles saving a new resume. Drafts are saved as they are;
+// validation happens when a saved resume is compiled.
+func CreateResume(c *gin.Context) {
+	request, ok := bindSavedResume(c)
+	if !ok {
+		return
+	}
+	note, ok := revisionNote(c)
+	if !ok {
+		return
+	}
+
+	if !checkBulletIDs(c, request) {
+		return
+	}
+	id, token, err := store.NewCredentials()
+	if err != nil {
+		storageError(c, err)
+		return
+	}
+	variant.AssignIDs(request)
+	r := &store.Resume{ID: id, TokenHash: store.HashToken(token), Data: *request}
+	if err := resumes.Create(c.Request.Context(), r, note); err != nil {
+		storageError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(r.Version))
+	c.Header("Location", "/api/resumes/"+r.ID)
+	c.JSON(http.StatusCreated, models.SavedResumeResponse{
+		Success:   true,
+		Resume:    savedResume(r),
+		EditToken: token,
+	})
+}
+
+// GetResume handles loading a saved resume. Anyone with the ID can read it.
+func GetResume(c *gin.Context) {
+	r, ok := loadResume(c)
+	if !ok {
+		return
+	}
+
+	c.Header("ETag", etag(r.Version))
+	if etagMatches(c.GetHeader("If-None-Match"), r.Version) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, models.SavedResumeResponse{Success: true, Resume: savedResume(r)})
+}
+
+// UpdateResume handles replacing a saved resume. It needs the edit token and
+// an If-Match header with the ETag the update is based on, so concurrent
+// edits cannot silently overwrite each other. Every update is kept as a
+// revision.
+func UpdateResume(c *gin.Context) {
+	request, ok := bindSavedResume(c)
+	if !ok {
+		return
+	}
+	note, ok := revisionNote(c)
+	if !ok {
+		return
+	}
+	r, ok := loadResume(c)
+	if !ok || !authorizeEdit(c, r) || !checkBulletIDs(c, request) {
+		return
+	}
+	saveRevision(c, r, request, note)
+}
+
+// checkBulletIDs rejects a resume whose bullet ID lists no longer line up
+// with their bullets, which would move IDs to other bullets
+func checkBulletIDs(c *gin.Context, data *models.ResumeRequest) bool {
+	if errs := variant.CheckBulletIDs(data); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Bullet IDs do not match their bullets",
+			Details: errs,
+		})
+		return false
+	}
+	return true
+}
+
+// saveRevision stores data as the next version of r, checking the If-Match
+// header, and writes the saved resume response
+func saveRevision(c *gin.Context, r *store.Resume, data *models.ResumeRequest, note string) {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, models.ErrorResponse{
+			Success: false,
+			Error:   "If-Match header is required",
+			Details: []string{"Send the ETag of the version being edited, e.g. " + etag(r.Version)},
+		})
+		return
+	}
+	version, ok := parseETag(ifMatch)
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid If-Match header",
+			Details: []string{fmt.Sprintf("%q is not an ETag issued by this server", ifMatch)},
+		})
+		return
+	}
+	if version == 0 {
+		version = r.Version
+	}
+
+	// Keep the stable IDs variants refer to; new items get fresh ones
+	variant.AssignIDs(data)
+	updated, err := resumes.Update(c.Request.Context(), r.ID, version, data, note)
+	if err != nil {
+		storageError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(updated.Version))
+	c.JSON(http.StatusOK, models.SavedResumeResponse{Success: true, Resume: savedResume(updated)})
+}
+
+// DeleteResume handles deleting a saved resume. It needs the edit token; an
+// If-Match header is honored when present.
+func DeleteResume(c *gin.Context) {
+	r, ok := loadResume(c)
+	if !ok || !authorizeEdit(c, r) {
+		return
+	}
+
+	version := 0
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		if version, ok = parseETag(ifMatch); !ok {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid If-Match header",
+				Details: []string{fmt.Sprintf("%q is not an ETag issued by this server", ifMatch)},
+			})
+			return
+		}
+	}
+
+	if err := resumes.Delete(c.Request.Context(), r.ID, version); err != nil {
+		storageError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CompileSavedResume handles compiling the current version of a saved resume
+func CompileSavedResume(c *gin.Context) {
+	r, ok := loadResume(c)
+	if !ok {
+		return
+	}
+
+	c.Header("ETag", etag(r.Version))
+	compileAndRespond(c, &r.Data)
+}
+
+// bindSavedResume decodes a resume body, writing the error response on failure
+func bindSavedResume(c *gin.Context) (*models.ResumeRequest, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSavedResumeBytes)
+
+	var request models.ResumeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: []string{err.Error()},
+		})
+		return nil, false
+	}
+	return &request, true
+}
+
+// revisionNote reads the optional "note" query parameter, writing the error
+// response when it is too long
+func revisionNote(c *gin.Context) (string, bool) {
+	note := strings.TrimSpace(c.Query("note"))
+	if len(note) > maxRevisionNoteLength {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: []string{fmt.Sprintf("Note must be at most %d characters", maxRevisionNoteLength)},
+		})
+		return "", false
+	}
+	return note, true
+}
+
+// loadResume loads the resume named in the URL, writing the error response
+// on failure
+func loadResume(c *gin.Context) (*store.Resume, bool) {
+	r, err := resumes.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		storageError(c, err)
+		return nil, false
+	}
+	return r, true
+}
+
+// authorizeEdit checks the "Authorization: Bearer <edit token>" header,
+// writing the error response when it is missing or wrong
+func authorizeEdit(c *gin.Context, r *store.Resume) bool {
+	token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !found || token == "" {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Edit token is required",
+			Details: []string{"Send the token returned on creation as \"Authorization: Bearer <token>\""},
+		})
+		return false
+	}
+	if !r.Authorize(strings.TrimSpace(token)) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid edit token",
+		})
+		return false
+	}
+	return true
+}
+
+// storageError writes the response for a store error
+func storageError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   "Resume not found",
+		})
+	case errors.Is(err, store.ErrRevisionNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   "Revision not found",
+		})
+	case errors.Is(err, store.ErrVersionMismatch):
+		c.JSON(http.StatusPreconditionFailed, models.ErrorResponse{
+			Success: false,
+			Error:   "Resume has been modified",
+			Details: []string{"Reload the resume and reapply the changes"},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   "Storage error",
+			Details: []string{err.Error()},
+		})
+	}
+}
+
+func savedResume(r *store.Resume) *models.SavedResume {
+	return &models.SavedResume{
+		ID:        r.ID,
+		Version:   r.Version,
+		Resume:    r.Data,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// etag formats a resume version as an ETag
+func etag(version int) string {
+	return fmt.Sprintf(`"v%d"`, version)
+}
+
+// parseETag reads the version from an If-Match value. "*" matches any
+// version and yields zero.
+func parseETag(value string) (int, bool) {
+	value = strings.TrimSpace(value)
+	if value == "*" {
+		return 0, true
+	}
+	value = strings.TrimPrefix(value, "W/")
+	value, ok := strings.CutPrefix(strings.Trim(value, `"`), "v")
+	if !ok {
+		return 0, false
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// etagMatches reports whether an If-None-Match list names the version
+func etagMatches(header string, version int) bool {
+	for _, value := range strings.Split(header, ",") {
+		if v, ok := parseETag(value); ok && (v == 0 || v == version) {
+			return true
+		}
+	}
+	return false
+}
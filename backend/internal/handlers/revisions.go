@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sahil/ats-resume-maker/backend/internal/diff"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+	"github.com/sahil/ats-resume-maker/backend/internal/store"
+)
+
+// ListRevisions handles listing the revisions of a saved resume, newest first
+func ListRevisions(c *gin.Context) {
+	revisions, err := resumes.Revisions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		storageError(c, err)
+		return
+	}
+
+	response := models.RevisionsResponse{Success: true, Revisions: make([]models.Revision, len(revisions))}
+	for i, rev := range revisions {
+		response.Revisions[i] = models.Revision{Version: rev.Version, Note: rev.Note, CreatedAt: rev.CreatedAt}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRevision handles loading one revision of a saved resume
+func GetRevision(c *gin.Context) {
+	rev, ok := loadRevision(c, c.Param("version"))
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, models.RevisionResponse{
+		Success:  true,
+		Revision: &models.Revision{Version: rev.Version, Note: rev.Note, CreatedAt: rev.CreatedAt, Resume: &rev.Data},
+	})
+}
+
+// DiffRevisions handles comparing two revisions of a saved resume. The "to"
+// query parameter defaults to the current version and "from" to the version
+// before "to".
+func DiffRevisions(c *gin.Context) {
+	r, ok := loadResume(c)
+	if !ok {
+		return
+	}
+
+	to, ok := versionParam(c, "to", r.Version)
+	if !ok {
+		return
+	}
+	from, ok := versionParam(c, "from", to-1)
+	if !ok {
+		return
+	}
+
+	older, ok := loadRevision(c, strconv.Itoa(from))
+	if !ok {
+		return
+	}
+	newer, ok := loadRevision(c, strconv.Itoa(to))
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DiffResponse{
+		Success: true,
+		From:    from,
+		To:      to,
+		Changes: diff.Compare(&older.Data, &newer.Data),
+	})
+}
+
+// RestoreRevision handles restoring an earlier revision. The restored content
+// is saved as a new revision, so the history stays intact; like an update it
+// needs the edit token and an If-Match header.
+func RestoreRevision(c *gin.Context) {
+	note, ok := revisionNote(c)
+	if !ok {
+		return
+	}
+	r, ok := loadResume(c)
+	if !ok || !authorizeEdit(c, r) {
+		return
+	}
+	rev, ok := loadRevision(c, c.Param("version"))
+	if !ok {
+		return
+	}
+
+	if note == "" {
+		note = fmt.Sprintf("Restored from version %d", rev.Version)
+	}
+	saveRevision(c, r, &rev.Data, note)
+}
+
+// loadRevision loads a revision of the resume named in the URL, writing the
+// error response on failure
+func loadRevision(c *gin.Context, version string) (*store.Revision, bool) {
+	v, err := strconv.Atoi(version)
+	if err != nil || v < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid revision",
+			Details: []string{fmt.Sprintf("%q is not a version number", version)},
+		})
+		return nil, false
+	}
+
+	rev, err := resumes.Revision(c.Request.Context(), c.Param("id"), v)
+	if err != nil {
+		storageError(c, err)
+		return nil, false
+	}
+	return rev, true
+}
+
+// versionParam reads a version number query parameter, writing the error
+// response when it is malformed
+func versionParam(c *gin.Context, name string, defaultVal int) (int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		if defaultVal < 1 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid revision",
+				Details: []string{fmt.Sprintf("The resume has no revision before version %d; set %q", defaultVal+1, name)},
+			})
+			return 0, false
+		}
+		return defaultVal, true
+	}
+
+	v, err := strconv.Atoi(value)
+	if err != nil || v < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid revision",
+			Details: []string{fmt.Sprintf("%q is not a version number", value)},
+		})
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/dates"
+	"github.com/sahil/ats-resume-maker/backend/internal/links"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+	"github.com/sahil/ats-resume-maker/backend/internal/skills"
+)
+
+// proficiencyLevels lists the accepted language proficiency values
+var proficiencyLevels = map[string]bool{
+	"native": true, "fluent": true, "professional": true, "intermediate": true, "basic": true,
+	"a1": true, "a2": true, "b1": true, "b2": true, "c1": true, "c2": true,
+}
+
+// validateSectionContent checks section content beyond dates: skill levels,
+// education GPAs, project links and the typed certifications, awards,
+// publications, languages, interests and custom sections
+func validateSectionContent(sections []models.Section) []string {
+	var errors []string
+
+	for i, section := range sections {
+		var errs []string
+		switch section.Type {
+		case "tech_skills":
+			errs = validateTechSkills(section.Content)
+		case "certifications":
+			errs = validateCertifications(section.Content)
+		case "awards":
+			errs = validateAwards(section.Content)
+		case "publications":
+			errs = validatePublications(section.Content)
+		case "languages":
+			errs = validateLanguages(section.Content)
+		case "interests":
+			var data models.InterestsContent
+			if err := models.DecodeContent(section.Content, &data); err != nil {
+				errs = append(errs, "invalid content: "+err.Error())
+			}
+		case "custom":
+			errs = validateCustom(section)
+		case "education":
+			errs = validateEducation(section.Content)
+		case "projects":
+			errs = validateProjects(section.Content)
+		}
+		for _, err := range errs {
+			errors = append(errors, fmt.Sprintf("Section %d (%s): %s", i+1, section.Type, err))
+		}
+	}
+
+	return errors
+}
+
+func validateTechSkills(content interface{}) []string {
+	var data models.TechSkillsContent
+	if err := models.DecodeContent(content, &data); err != nil {
+		return []string{"invalid content: " + err.Error()}
+	}
+
+	var errors []string
+	switch data.Layout {
+	case "", models.SkillsLayoutTable, models.SkillsLayoutList, models.SkillsLayoutLevels:
+	default:
+		errors = append(errors, fmt.Sprintf("layout must be %q, %q or %q", models.SkillsLayoutTable, models.SkillsLayoutList, models.SkillsLayoutLevels))
+	}
+	for i, cat := range data.Categories {
+		for j, item := range cat.Items {
+			if strings.TrimSpace(item.Name) == "" {
+				errors = append(errors, fmt.Sprintf("category %d, skill %d: name is required", i+1, j+1))
+			}
+			if !skills.ValidLevel(item.Level) {
+				errors = append(errors, fmt.Sprintf("category %d, skill %d: level must be one of %s", i+1, j+1, strings.Join(skills.Levels, ", ")))
+			}
+		}
+	}
+	return errors
+}
+
+func validateCertifications(content interface{}) []string {
+	var data models.CertificationsContent
+	if err := models.DecodeContent(content, &data); err != nil {
+		return []string{"invalid content: " + err.Error()}
+	}
+
+	var errors []string
+	for i, e := range data.Entries {
+		if strings.TrimSpace(e.Name) == "" {
+			errors = append(errors, fmt.Sprintf("entry %d: name is required", i+1))
+		}
+		if err := checkRange(e.Date, e.ExpiryDate, false); err != nil {
+			errors = append(errors, fmt.Sprintf("entry %d: %s", i+1, err.Error()))
+		}
+		if e.URL != "" {
+			if _, err := links.Normalize("", "", e.URL); err != nil {
+				errors = append(errors, fmt.Sprintf("entry %d: %s", i+1, err.Error()))
+			}
+		}
+	}
+	return errors
+}
+
+func validateAwards(content interface{}) []string {
+	var data models.AwardsContent
+	if err := models.DecodeContent(content, &data); err != nil {
+		return []string{"invalid content: " + err.Error()}
+	}
+
+	var errors []string
+	for i, e := range data.Entries {
+		if strings.TrimSpace(e.Title) == "" {
+			errors = append(errors, fmt.Sprintf("entry %d: title is required", i+1))
+		}
+	}
+	return errors
+}
+
+func validatePublications(content interface{}) []string {
+	var data models.PublicationsContent
+	if err := models.DecodeContent(content, &data); err != nil {
+		return []string{"invalid content: " + err.Error()}
+	}
+
+	var errors []string
+	for i, e := range data.Entries {
+		if strings.TrimSpace(e.Title) == "" {
+			errors = append(errors, fmt.Sprintf("entry %d: title is required", i+1))
+		}
+		if e.Year != "" {
+			if d, err := dates.Parse(e.Year); err != nil || d.Month != 0 || d.Expected {
+				errors = append(errors, fmt.Sprintf("entry %d: year must be four digits, e.g. 2021", i+1))
+			}
+		}
+		if e.DOI != "" {
+			if _, err := links.NormalizeDOI(e.DOI); err != nil {
+				errors = append(errors, fmt.Sprintf("entry %d: %s", i+1, err.Error()))
+			}
+		}
+		if e.URL != "" {
+			if _, err := links.Normalize("", "", e.URL); err != nil {
+				errors = append(errors, fmt.Sprintf("entry %d: %s", i+1, err.Error()))
+			}
+		}
+	}
+	return errors
+}
+
+func validateLanguages(content interface{}) []string {
+	var data models.LanguagesContent
+	if err := models.DecodeContent(content, &data); err != nil {
+		return []string{"invalid content: " + err.Error()}
+	}
+
+	var errors []string
+	for i, e := range data.Entries {
+		if strings.TrimSpace(e.Name) == "" {
+			errors = append(errors, fmt.Sprintf("entry %d: name is required", i+1))
+		}
+		if e.Proficiency != "" && !proficiencyLevels[strings.ToLower(e.Proficiency)] {
+			errors = append(errors, fmt.Sprintf("entry %d: unsupported proficiency %q", i+1, e.Proficiency))
+		}
+	}
+	return errors
+}
+
+func validateCustom(section models.Section) []string {
+	var data models.CustomContent
+	if err := models.DecodeContent(section.Content, &data); err != nil {
+		return []string{"invalid content: " + err.Error()}
+	}
+
+	var errors []string
+	if strings.TrimSpace(section.Title) == "" {
+		errors = append(errors, "title is required for custom sections")
+	}
+	switch data.Layout {
+	case models.CustomLayoutBullets, models.CustomLayoutTable, models.CustomLayoutParagraph:
+	case models.CustomLayoutEntries:
+		for i, e := range data.Entries {
+			if strings.TrimSpace(e.Title) == "" {
+				errors = append(errors, fmt.Sprintf("entry %d: title is required", i+1))
+			}
+			if err := checkRange(e.StartDate, e.EndDate, e.Current); err != nil {
+				errors = append(errors, fmt.Sprintf("entry %d: %s", i+1, err.Error()))
+			}
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("unsupported layout %q, use bullets, table, entries or paragraph", data.Layout))
+	}
+	return errors
+}
+
+// validateEducation checks that GPAs are numeric and within their scale.
+// GPAs may be sent as JSON strings or numbers.
+func validateEducation(content interface{}) []string {
+	data, _ := content.(map[string]interface{})
+	entries, _ := data["entries"].([]interface{})
+
+	parse := func(v interface{}) (float64, bool, error) {
+		switch val := v.(type) {
+		case nil:
+			return 0, false, nil
+		case float64:
+			return val, true, nil
+		case string:
+			if strings.TrimSpace(val) == "" {
+				return 0, false, nil
+			}
+			f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+			return f, true, err
+		}
+		return 0, false, fmt.Errorf("not a number")
+	}
+
+	var errors []string
+	for i, entry := range entries {
+		e, _ := entry.(map[string]interface{})
+		gpa, hasGPA, err := parse(e["gpa"])
+		if err != nil || gpa < 0 {
+			errors = append(errors, fmt.Sprintf("entry %d: GPA must be a non-negative number", i+1))
+			continue
+		}
+		scale, hasScale, err := parse(e["gpaScale"])
+		if err != nil || (hasScale && scale <= 0) {
+			errors = append(errors, fmt.Sprintf("entry %d: GPA scale must be a positive number", i+1))
+			continue
+		}
+		if hasGPA && hasScale && gpa > scale {
+			errors = append(errors, fmt.Sprintf("entry %d: GPA %v exceeds scale %v", i+1, gpa, scale))
+		}
+	}
+	return errors
+}
+
+func validateProjects(content interface{}) []string {
+	var data models.ProjectsContent
+	if err := models.DecodeContent(content, &data); err != nil {
+		return []string{"invalid content: " + err.Error()}
+	}
+
+	var errors []string
+	switch data.TechStyle {
+	case "", models.TechStyleSuffix, models.TechStyleLine, models.TechStyleHidden:
+	default:
+		errors = append(errors, fmt.Sprintf("unsupported tech style %q", data.TechStyle))
+	}
+	for i, e := range data.Entries {
+		for j, link := range e.Links {
+			if _, err := links.NormalizeProjectLink(link.Kind, link.Label, link.URL); err != nil {
+				errors = append(errors, fmt.Sprintf("entry %d, link %d: %s", i+1, j+1, err.Error()))
+			}
+		}
+	}
+	return errors
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+	"github.com/sahil/ats-resume-maker/backend/internal/store"
+	"github.com/sahil/ats-resume-maker/backend/internal/variant"
+)
+
+// CreateVariant handles saving a new variant of a saved resume. Variants
+// belong to the master, so they are changed with the master's edit token.
+func CreateVariant(c *gin.Context) {
+	def, ok := bindVariant(c)
+	if !ok {
+		return
+	}
+	master, ok := loadResume(c)
+	if !ok || !authorizeEdit(c, master) || !validateVariant(c, master, def) {
+		return
+	}
+
+	id, err := store.NewVariantID()
+	if err != nil {
+		storageError(c, err)
+		return
+	}
+	v := &store.Variant{ID: id, MasterID: master.ID, Data: *def}
+	if err := resumes.CreateVariant(c.Request.Context(), v); err != nil {
+		variantError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(v.Version))
+	c.Header("Location", "/api/resumes/"+master.ID+"/variants/"+v.ID)
+	respondVariant(c, http.StatusCreated, master, v)
+}
+
+// ListVariants handles listing the variants of a saved resume
+func ListVariants(c *gin.Context) {
+	variants, err := resumes.Variants(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		variantError(c, err)
+		return
+	}
+
+	response := models.VariantsResponse{Success: true, Variants: make([]models.SavedVariant, len(variants))}
+	for i := range variants {
+		response.Variants[i] = *savedVariant(&variants[i])
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetVariant handles loading a variant together with the resume it produces
+// from the current master
+func GetVariant(c *gin.Context) {
+	master, ok := loadResume(c)
+	if !ok {
+		return
+	}
+	v, ok := loadVariant(c)
+	if !ok {
+		return
+	}
+
+	c.Header("ETag", etag(v.Version))
+	respondVariant(c, http.StatusOK, master, v)
+}
+
+// UpdateVariant handles replacing a variant definition. Like a resume update
+// it needs the edit token and an If-Match header with the variant's ETag.
+func UpdateVariant(c *gin.Context) {
+	def, ok := bindVariant(c)
+	if !ok {
+		return
+	}
+	master, ok := loadResume(c)
+	if !ok || !authorizeEdit(c, master) {
+		return
+	}
+	v, ok := loadVariant(c)
+	if !ok || !validateVariant(c, master, def) {
+		return
+	}
+
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, models.ErrorResponse{
+			Success: false,
+			Error:   "If-Match header is required",
+			Details: []string{"Send the ETag of the version being edited, e.g. " + etag(v.Version)},
+		})
+		return
+	}
+	version, ok := parseETag(ifMatch)
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid If-Match header",
+			Details: []string{fmt.Sprintf("%q is not an ETag issued by this server", ifMatch)},
+		})
+		return
+	}
+	if version == 0 {
+		version = v.Version
+	}
+
+	updated, err := resumes.UpdateVariant(c.Request.Context(), master.ID, v.ID, version, def)
+	if err != nil {
+		variantError(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(updated.Version))
+	respondVariant(c, http.StatusOK, master, updated)
+}
+
+// DeleteVariant handles deleting a variant. It needs the master's edit
+// token; an If-Match header is honored when present.
+func DeleteVariant(c *gin.Context) {
+	master, ok := loadResume(c)
+	if !ok || !authorizeEdit(c, master) {
+		return
+	}
+
+	version := 0
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		if version, ok = parseETag(ifMatch); !ok {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid If-Match header",
+				Details: []string{fmt.Sprintf("%q is not an ETag issued by this server", ifMatch)},
+			})
+			return
+		}
+	}
+
+	if err := resumes.DeleteVariant(c.Request.Context(), master.ID, c.Param("variantId"), version); err != nil {
+		variantError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CompileVariant handles compiling a variant applied to the current version
+// of its master
+func CompileVariant(c *gin.Context) {
+	master, ok := loadResume(c)
+	if !ok {
+		return
+	}
+	v, ok := loadVariant(c)
+	if !ok {
+		return
+	}
+
+	resolved, err := variant.Apply(&master.Data, &v.Data)
+	if err != nil {
+		storageError(c, err)
+		return
+	}
+	c.Header("ETag", etag(v.Version))
+	compileAndRespond(c, resolved)
+}
+
+// bindVariant decodes a variant body, writing the error response on failure
+func bindVariant(c *gin.Context) (*models.Variant, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSavedResumeBytes)
+
+	var def models.Variant
+	if err := c.ShouldBindJSON(&def); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: []string{err.Error()},
+		})
+		return nil, false
+	}
+	return &def, true
+}
+
+// validateVariant checks a variant against its master, writing the error
+// response when it is invalid
+func validateVariant(c *gin.Context, master *store.Resume, def *models.Variant) bool {
+	errs := append(variant.Validate(&master.Data, def), validatePDFOptions(def.PDF)...)
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: errs,
+		})
+		return false
+	}
+	return true
+}
+
+// loadVariant loads the variant named in the URL, writing the error response
+// on failure
+func loadVariant(c *gin.Context) (*store.Variant, bool) {
+	v, err := resumes.Variant(c.Request.Context(), c.Param("id"), c.Param("variantId"))
+	if err != nil {
+		variantError(c, err)
+		return nil, false
+	}
+	return v, true
+}
+
+// variantError writes the response for a store error on a variant
+func variantError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, store.ErrVariantNotFound):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   "Variant not found",
+		})
+	case errors.Is(err, store.ErrVersionMismatch):
+		c.JSON(http.StatusPreconditionFailed, models.ErrorResponse{
+			Success: false,
+			Error:   "Variant has been modified",
+			Details: []string{"Reload the variant and reapply the changes"},
+		})
+	default:
+		storageError(c, err)
+	}
+}
+
+// respondVariant writes a variant along with the resume it produces from the
+// master
+func respondVariant(c *gin.Context, status int, master *store.Resume, v *store.Variant) {
+	saved := savedVariant(v)
+	resolved, err := variant.Apply(&master.Data, &v.Data)
+	if err != nil {
+		storageError(c, err)
+		return
+	}
+	saved.Resume = resolved
+	c.JSON(status, models.VariantResponse{Success: true, Variant: saved})
+}
+
+func savedVariant(v *store.Variant) *models.SavedVariant {
+	return &models.SavedVariant{
+		ID:        v.ID,
+		MasterID:  v.MasterID,
+		Version:   v.Version,
+		Variant:   v.Data,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
@@ -0,0 +1,383 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is used when a request does not specify one
+const DefaultLocale = "en"
+
+// Locale holds the translated strings used when rendering a resume
+type Locale struct {
+	Code        string
+	Headings    map[string]string // Section type -> heading
+	Months      [12]string
+	ShortMonths [12]string
+	Present     string
+	Expected    string // Prefix for future dates, e.g. "Expected May 2026"
+	DayFormat   string // Format of a full date given day, month name and year, e.g. "%[2]s %[1]d, %[3]d"
+
+	// Tenure units, singular and plural, e.g. "1 yr", "2 yrs"
+	YearUnit, YearsUnit   string
+	MonthUnit, MonthsUnit string
+
+	Proficiency map[string]string // Language proficiency level -> label
+	Labels      map[string]string // Inline field labels, e.g. "gpa" -> "GPA"
+}
+
+var locales = map[string]*Locale{
+	"en": {
+		Code: "en",
+		Headings: map[string]string{
+			"profile_summary": "OBJECTIVE",
+			"tech_skills":     "SKILLS",
+			"experience":      "EXPERIENCE",
+			"projects":        "PROJECTS",
+			"volunteer":       "VOLUNTEER EXPERIENCE",
+			"education":       "EDUCATION",
+			"certifications":  "CERTIFICATIONS",
+			"awards":          "AWARDS",
+			"publications":    "PUBLICATIONS",
+			"languages":       "LANGUAGES",
+			"interests":       "INTERESTS",
+		},
+		Months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Present:     "Present",
+		Expected:    "Expected",
+		DayFormat:   "%[2]s %[1]d, %[3]d",
+		YearUnit:    "yr",
+		YearsUnit:   "yrs",
+		MonthUnit:   "mo",
+		MonthsUnit:  "mos",
+		Proficiency: map[string]string{
+			"native":       "Native",
+			"fluent":       "Fluent",
+			"professional": "Professional",
+			"intermediate": "Intermediate",
+			"basic":        "Basic",
+		},
+		Labels: map[string]string{
+			"gpa":          "GPA",
+			"honors":       "Honors",
+			"minors":       "Minor",
+			"thesis":       "Thesis",
+			"coursework":   "Relevant Coursework",
+			"technologies": "Technologies",
+			"expert":       "Expert",
+			"advanced":     "Advanced",
+			"intermediate": "Intermediate",
+			"beginner":     "Beginner",
+			"other":        "Other",
+			"resume":       "Resume",
+			"coverLetter":  "Cover Letter",
+			"salutation":   "Dear Hiring Manager,",
+			"salutationTo": "Dear %s,",
+			"closing":      "Sincerely,",
+		},
+	},
+	"fr": {
+		Code: "fr",
+		Headings: map[string]string{
+			"profile_summary": "PROFIL",
+			"tech_skills":     "COMPÉTENCES",
+			"experience":      "EXPÉRIENCE PROFESSIONNELLE",
+			"projects":        "PROJETS",
+			"volunteer":       "BÉNÉVOLAT",
+			"education":       "FORMATION",
+			"certifications":  "CERTIFICATIONS",
+			"awards":          "DISTINCTIONS",
+			"publications":    "PUBLICATIONS",
+			"languages":       "LANGUES",
+			"interests":       "CENTRES D'INTÉRÊT",
+		},
+		Months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Present:     "aujourd'hui",
+		Expected:    "Prévu",
+		DayFormat:   "%[1]d %[2]s %[3]d",
+		YearUnit:    "an",
+		YearsUnit:   "ans",
+		MonthUnit:   "mois",
+		MonthsUnit:  "mois",
+		Proficiency: map[string]string{
+			"native":       "Langue maternelle",
+			"fluent":       "Courant",
+			"professional": "Professionnel",
+			"intermediate": "Intermédiaire",
+			"basic":        "Notions",
+		},
+		Labels: map[string]string{
+			"gpa":          "Moyenne",
+			"honors":       "Distinctions",
+			"minors":       "Mineure",
+			"thesis":       "Mémoire",
+			"coursework":   "Cours pertinents",
+			"technologies": "Technologies",
+			"expert":       "Expert",
+			"advanced":     "Avancé",
+			"intermediate": "Intermédiaire",
+			"beginner":     "Débutant",
+			"other":        "Autres",
+			"resume":       "CV",
+			"coverLetter":  "Lettre de motivation",
+			"salutation":   "Madame, Monsieur,",
+			"salutationTo": "Bonjour %s,",
+			"closing":      "Cordialement,",
+		},
+	},
+	"de": {
+		Code: "de",
+		Headings: map[string]string{
+			"profile_summary": "PROFIL",
+			"tech_skills":     "KENNTNISSE",
+			"experience":      "BERUFSERFAHRUNG",
+			"projects":        "PROJEKTE",
+			"volunteer":       "EHRENAMT",
+			"education":       "AUSBILDUNG",
+			"certifications":  "ZERTIFIZIERUNGEN",
+			"awards":          "AUSZEICHNUNGEN",
+			"publications":    "PUBLIKATIONEN",
+			"languages":       "SPRACHEN",
+			"interests":       "INTERESSEN",
+		},
+		Months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		Present:     "heute",
+		Expected:    "Voraussichtlich",
+		DayFormat:   "%[1]d. %[2]s %[3]d",
+		YearUnit:    "J.",
+		YearsUnit:   "J.",
+		MonthUnit:   "Mon.",
+		MonthsUnit:  "Mon.",
+		Proficiency: map[string]string{
+			"native":       "Muttersprache",
+			"fluent":       "Fließend",
+			"professional": "Verhandlungssicher",
+			"intermediate": "Fortgeschritten",
+			"basic":        "Grundkenntnisse",
+		},
+		Labels: map[string]string{
+			"gpa":          "Note",
+			"honors":       "Auszeichnungen",
+			"minors":       "Nebenfach",
+			"thesis":       "Abschlussarbeit",
+			"coursework":   "Relevante Kurse",
+			"technologies": "Technologien",
+			"expert":       "Experte",
+			"advanced":     "Fortgeschritten",
+			"intermediate": "Mittelstufe",
+			"beginner":     "Grundkenntnisse",
+			"other":        "Sonstige",
+			"resume":       "Lebenslauf",
+			"coverLetter":  "Anschreiben",
+			"salutation":   "Sehr geehrte Damen und Herren,",
+			"salutationTo": "Guten Tag %s,",
+			"closing":      "Mit freundlichen Grüßen",
+		},
+	},
+	"es": {
+		Code: "es",
+		Headings: map[string]string{
+			"profile_summary": "PERFIL",
+			"tech_skills":     "HABILIDADES",
+			"experience":      "EXPERIENCIA",
+			"projects":        "PROYECTOS",
+			"volunteer":       "VOLUNTARIADO",
+			"education":       "EDUCACIÓN",
+			"certifications":  "CERTIFICACIONES",
+			"awards":          "PREMIOS",
+			"publications":    "PUBLICACIONES",
+			"languages":       "IDIOMAS",
+			"interests":       "INTERESES",
+		},
+		Months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths: [12]string{"ene.", "feb.", "mar.", "abr.", "may.", "jun.", "jul.", "ago.", "sept.", "oct.", "nov.", "dic."},
+		Present:     "actualidad",
+		Expected:    "Previsto",
+		DayFormat:   "%[1]d de %[2]s de %[3]d",
+		YearUnit:    "año",
+		YearsUnit:   "años",
+		MonthUnit:   "mes",
+		MonthsUnit:  "meses",
+		Proficiency: map[string]string{
+			"native":       "Nativo",
+			"fluent":       "Fluido",
+			"professional": "Profesional",
+			"intermediate": "Intermedio",
+			"basic":        "Básico",
+		},
+		Labels: map[string]string{
+			"gpa":          "Promedio",
+			"honors":       "Honores",
+			"minors":       "Especialidad secundaria",
+			"thesis":       "Tesis",
+			"coursework":   "Cursos relevantes",
+			"technologies": "Tecnologías",
+			"expert":       "Experto",
+			"advanced":     "Avanzado",
+			"intermediate": "Intermedio",
+			"beginner":     "Principiante",
+			"other":        "Otros",
+			"resume":       "Currículum",
+			"coverLetter":  "Carta de presentación",
+			"salutation":   "Estimado equipo de selección:",
+			"salutationTo": "Estimado/a %s:",
+			"closing":      "Atentamente,",
+		},
+	},
+	"hi": {
+		Code: "hi",
+		Headings: map[string]string{
+			"profile_summary": "सारांश",
+			"tech_skills":     "कौशल",
+			"experience":      "अनुभव",
+			"projects":        "परियोजनाएँ",
+			"volunteer":       "स्वयंसेवा",
+			"education":       "शिक्षा",
+			"certifications":  "प्रमाणपत्र",
+			"awards":          "पुरस्कार",
+			"publications":    "प्रकाशन",
+			"languages":       "भाषाएँ",
+			"interests":       "रुचियाँ",
+		},
+		Months:      [12]string{"जनवरी", "फ़रवरी", "मार्च", "अप्रैल", "मई", "जून", "जुलाई", "अगस्त", "सितंबर", "अक्टूबर", "नवंबर", "दिसंबर"},
+		ShortMonths: [12]string{"जनवरी", "फ़रवरी", "मार्च", "अप्रैल", "मई", "जून", "जुलाई", "अगस्त", "सितंबर", "अक्टूबर", "नवंबर", "दिसंबर"},
+		Present:     "वर्तमान",
+		Expected:    "अपेक्षित",
+		DayFormat:   "%[1]d %[2]s %[3]d",
+		YearUnit:    "वर्ष",
+		YearsUnit:   "वर्ष",
+		MonthUnit:   "माह",
+		MonthsUnit:  "माह",
+		Proficiency: map[string]string{
+			"native":       "मातृभाषा",
+			"fluent":       "धाराप्रवाह",
+			"professional": "व्यावसायिक",
+			"intermediate": "मध्यम",
+			"basic":        "प्रारंभिक",
+		},
+		Labels: map[string]string{
+			"gpa":          "जीपीए",
+			"honors":       "सम्मान",
+			"minors":       "गौण विषय",
+			"thesis":       "शोध प्रबंध",
+			"coursework":   "प्रासंगिक पाठ्यक्रम",
+			"technologies": "तकनीकें",
+			"expert":       "विशेषज्ञ",
+			"advanced":     "उन्नत",
+			"intermediate": "मध्यम",
+			"beginner":     "प्रारंभिक",
+			"other":        "अन्य",
+			"resume":       "बायोडाटा",
+			"coverLetter":  "आवेदन पत्र",
+			"salutation":   "प्रिय नियुक्ति प्रबंधक,",
+			"salutationTo": "प्रिय %s,",
+			"closing":      "भवदीय,",
+		},
+	},
+}
+
+// Lookup returns the locale for a language tag such as "fr", "fr-CA" or
+// "fr_CA". An empty tag resolves to the default locale.
+func Lookup(tag string) (*Locale, bool) {
+	if tag == "" {
+		return locales[DefaultLocale], true
+	}
+	lang, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	l, ok := locales[strings.ToLower(lang)]
+	return l, ok
+}
+
+// All returns the supported locales, ordered by code
+func All() []*Locale {
+	codes := make([]string, 0, len(locales))
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	all := make([]*Locale, len(codes))
+	for i, code := range codes {
+		all[i] = locales[code]
+	}
+	return all
+}
+
+// Default returns the default locale
+func Default() *Locale {
+	return locales[DefaultLocale]
+}
+
+// Heading returns the translated heading for a section type, or an empty
+// string when the type has no built-in heading
+func (l *Locale) Heading(sectionType string) string {
+	if h, ok := l.Headings[sectionType]; ok {
+		return h
+	}
+	return locales[DefaultLocale].Headings[sectionType]
+}
+
+// MonthName returns the full or abbreviated name of a month
+func (l *Locale) MonthName(m time.Month, short bool) string {
+	if short {
+		return l.ShortMonths[m-1]
+	}
+	return l.Months[m-1]
+}
+
+// FormatDay renders a full date such as the date line of a letter, e.g.
+// "October 19, 2026"
+func (l *Locale) FormatDay(t time.Time) string {
+	return fmt.Sprintf(l.DayFormat, t.Day(), l.MonthName(t.Month(), false), t.Year())
+}
+
+// englishDateWord matches English month names and "present" markers in
+// free-form date strings
+var englishDateWord = regexp.MustCompile(`(?i)\b(jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?|present|current|now)\b\.?`)
+
+// LocalizeDate translates English month names and "Present" in a free-form
+// date string, keeping abbreviated months abbreviated
+func (l *Locale) LocalizeDate(s string) string {
+	if l.Code == DefaultLocale {
+		return s
+	}
+	return englishDateWord.ReplaceAllStringFunc(s, func(word string) string {
+		w := strings.ToLower(strings.TrimSuffix(word, "."))
+		switch w {
+		case "present", "current", "now":
+			return l.Present
+		}
+		for i, name := range locales[DefaultLocale].Months {
+			full := strings.ToLower(name)
+			if w == full {
+				return l.Months[i]
+			}
+			if strings.HasPrefix(full, w) {
+				return l.ShortMonths[i]
+			}
+		}
+		return word
+	})
+}
+
+// ProficiencyLabel returns the translated label for a language proficiency
+// level. CEFR levels such as "B2" and unknown values are returned as given.
+func (l *Locale) ProficiencyLabel(level string) string {
+	if label, ok := l.Proficiency[strings.ToLower(level)]; ok {
+		return label
+	}
+	return level
+}
+
+// Label returns a translated inline field label, falling back to English
+func (l *Locale) Label(key string) string {
+	if label, ok := l.Labels[key]; ok {
+		return label
+	}
+	return locales[DefaultLocale].Labels[key]
+}
@@ -0,0 +1,390 @@
+// Package importer reads resumes written in other formats into the request
+// model. Imports are best effort: every field carries a confidence score so
+// the user knows what to check.
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/dates"
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+	"github.com/sahil/ats-resume-maker/backend/internal/links"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+	"github.com/sahil/ats-resume-maker/backend/internal/phone"
+)
+
+// Confidence levels shared by the importers
+const (
+	confident = 0.9 // Read from an unambiguous marker, e.g. an email address
+	likely    = 0.7 // Read from the expected position in a known layout
+	guessed   = 0.5 // Inferred from loose patterns
+)
+
+// builder accumulates an imported resume with a confidence score per field
+type builder struct {
+	result models.ImportResult
+}
+
+func newBuilder() *builder {
+	return &builder{result: models.ImportResult{Confidence: make(map[string]float64)}}
+}
+
+// score records the confidence of a field when it holds a value
+func (b *builder) score(path, value string, confidence float64) {
+	if strings.TrimSpace(value) != "" {
+		b.result.Confidence[path] = confidence
+	}
+}
+
+func (b *builder) warn(format string, args ...interface{}) {
+	b.result.Warnings = append(b.result.Warnings, fmt.Sprintf(format, args...))
+}
+
+// addSection appends a section and returns its JSON path
+func (b *builder) addSection(sectionType, title string, content interface{}, confidence float64) string {
+	path := fmt.Sprintf("sections.%d", len(b.result.Resume.Sections))
+	b.result.Resume.Sections = append(b.result.Resume.Sections, models.Section{Type: sectionType, Title: title, Content: content})
+	b.result.Confidence[path+".type"] = confidence
+	return path
+}
+
+// finish returns the result with section content converted to the generic
+// JSON form the rest of the backend reads
+func (b *builder) finish() (*models.ImportResult, error) {
+	data, err := json.Marshal(b.result.Resume)
+	if err != nil {
+		return nil, err
+	}
+	var req models.ResumeRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	b.result.Resume = req
+	if b.result.Resume.Sections == nil {
+		b.result.Resume.Sections = []models.Section{}
+	}
+	return &b.result, nil
+}
+
+// headingAliases are common English headings beyond the built-in ones
+var headingAliases = map[string]string{
+	"SUMMARY":                   "profile_summary",
+	"PROFILE":                   "profile_summary",
+	"PROFESSIONAL SUMMARY":      "profile_summary",
+	"CAREER OBJECTIVE":          "profile_summary",
+	"ABOUT ME":                  "profile_summary",
+	"TECHNICAL SKILLS":          "tech_skills",
+	"SKILLS & TECHNOLOGIES":     "tech_skills",
+	"CORE COMPETENCIES":         "tech_skills",
+	"TECHNOLOGIES":              "tech_skills",
+	"WORK EXPERIENCE":           "experience",
+	"PROFESSIONAL EXPERIENCE":   "experience",
+	"EMPLOYMENT":                "experience",
+	"EMPLOYMENT HISTORY":        "experience",
+	"WORK HISTORY":              "experience",
+	"PERSONAL PROJECTS":         "projects",
+	"SIDE PROJECTS":             "projects",
+	"VOLUNTEERING":              "volunteer",
+	"VOLUNTEER":                 "volunteer",
+	"ACADEMIC BACKGROUND":       "education",
+	"CERTIFICATES":              "certifications",
+	"LICENSES & CERTIFICATIONS": "certifications",
+	"HONORS & AWARDS":           "awards",
+	"ACHIEVEMENTS":              "awards",
+	"HOBBIES":                   "interests",
+}
+
+// sectionType recognizes a section heading in any supported locale
+func sectionType(heading string) (string, bool) {
+	key := strings.ToUpper(strings.Join(strings.Fields(heading), " "))
+	key = strings.TrimSuffix(key, ":")
+	if key == "" {
+		return "", false
+	}
+	for _, locale := range i18n.All() {
+		for sectionType, h := range locale.Headings {
+			if strings.ToUpper(h) == key {
+				return sectionType, true
+			}
+		}
+	}
+	t, ok := headingAliases[key]
+	return t, ok
+}
+
+// looksLikeHeading reports whether a line could be an unrecognized section
+// heading: a few upper-case words without digits or punctuation marks
+func looksLikeHeading(s string) bool {
+	words := strings.Fields(s)
+	if len(words) == 0 || len(words) > 4 {
+		return false
+	}
+	letters := 0
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r):
+			if unicode.IsLower(r) {
+				return false
+			}
+			letters++
+		case unicode.IsDigit(r), strings.ContainsRune("@.,:;()|/", r):
+			return false
+		}
+	}
+	return letters >= 3
+}
+
+// rangeSeparators split a date range, e.g. "Jan 2020 – Present", most
+// specific first so a bare hyphen does not split "2020-01"
+var rangeSeparators = []string{"–", "—", "‒", " - ", " to ", "-"}
+
+// parseDate reads a date printed in any supported locale and returns it in
+// the canonical English form, e.g. "janv. 2020" becomes "Jan 2020"
+func parseDate(s string) (string, bool) {
+	s = strings.TrimSpace(strings.Trim(strings.TrimSpace(s), "(){}"))
+	if s == "" {
+		return "", false
+	}
+	if d, err := dates.Parse(s); err == nil {
+		return d.Format(dates.StyleShort, i18n.Default()), true
+	}
+	if d, err := dates.Parse(englishDate(s)); err == nil {
+		return d.Format(dates.StyleShort, i18n.Default()), true
+	}
+	return "", false
+}
+
+// englishDate translates localized month names and "present" words back
+// to English
+func englishDate(s string) string {
+	en := i18n.Default()
+	lower := strings.ToLower(s)
+	for _, locale := range i18n.All() {
+		if locale == en {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(lower, strings.ToLower(locale.Expected)+" "); ok {
+			return "Expected " + englishDate(rest)
+		}
+		if lower == strings.ToLower(locale.Present) {
+			return en.Present
+		}
+		for i := range locale.Months {
+			for _, name := range []string{locale.Months[i], locale.ShortMonths[i]} {
+				name = strings.ToLower(name)
+				if rest, ok := strings.CutPrefix(lower, name+" "); ok {
+					return en.ShortMonths[i] + " " + rest
+				}
+			}
+		}
+	}
+	return s
+}
+
+// dateRange reads a single date or a start/end pair
+func dateRange(s string) (start, end string, ok bool) {
+	if start, ok = parseDate(s); ok {
+		return start, "", true
+	}
+	for _, sep := range rangeSeparators {
+		parts := strings.Split(s, sep)
+		if len(parts) != 2 {
+			continue
+		}
+		start, okStart := parseDate(parts[0])
+		end, okEnd := parseDate(parts[1])
+		if okStart && okEnd {
+			return start, end, true
+		}
+	}
+	return "", "", false
+}
+
+// trailingDate splits a date or date range off the end of a line of text,
+// e.g. "Software Engineer Jan 2020 – Present"
+func trailingDate(s string) (text, start, end string, ok bool) {
+	words := strings.Fields(s)
+	// Ranges span at most seven words, e.g. "Expected Sep 2020 – Expected May 2024"
+	for n := min(len(words), 7); n > 0; n-- {
+		if start, end, ok = dateRange(strings.Join(words[len(words)-n:], " ")); ok {
+			return strings.Join(words[:len(words)-n], " "), start, end, true
+		}
+	}
+	return s, "", "", false
+}
+
+var (
+	emailRegex   = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phoneRegex   = regexp.MustCompile(`^\+?[\d\s().-]{7,}$`)
+	urlRegex     = regexp.MustCompile(`^(https?://)?([A-Za-z0-9-]+\.)+[A-Za-z]{2,}(/\S*)?$`)
+	placeRegex   = regexp.MustCompile(`^([\p{L} .'-]+),\s*([\p{L} .'-]+)$`)
+	separatorSet = "⋄◇♦|•·"
+)
+
+// readContacts fills the basic details from header text such as
+// "+1 (416) 555-1234 ⋄ Toronto, ON"; the parts may also be given one by one
+func (b *builder) readContacts(parts []string) {
+	bd := &b.result.Resume.BasicDetails
+	for _, part := range parts {
+		for _, token := range strings.FieldsFunc(part, func(r rune) bool { return strings.ContainsRune(separatorSet, r) }) {
+			b.readContact(bd, strings.TrimSpace(token))
+		}
+	}
+}
+
+func (b *builder) readContact(bd *models.BasicDetails, token string) {
+	switch {
+	case token == "":
+	case emailRegex.MatchString(token) && bd.Email == "":
+		bd.Email = emailRegex.FindString(token)
+		b.score("basicDetails.email", bd.Email, confident)
+	case phoneRegex.MatchString(token) && bd.Phone == "":
+		if num, err := phone.Parse(token); err == nil {
+			bd.Phone = num.E164()
+			b.score("basicDetails.phone", bd.Phone, confident)
+		} else {
+			bd.Phone = token
+			b.score("basicDetails.phone", bd.Phone, guessed)
+		}
+	case urlRegex.MatchString(token):
+		b.readLink(bd, token)
+	case placeRegex.MatchString(token) && bd.City == "":
+		m := placeRegex.FindStringSubmatch(token)
+		bd.City, bd.Province = strings.TrimSpace(m[1]), strings.TrimSpace(m[2])
+		b.score("basicDetails.city", bd.City, likely)
+		b.score("basicDetails.province", bd.Province, likely)
+	default:
+		b.warn("Unrecognized contact detail %q", token)
+	}
+}
+
+// readLink files a URL under the legacy GitHub and LinkedIn fields or the
+// generic links list
+func (b *builder) readLink(bd *models.BasicDetails, raw string) {
+	lower := strings.ToLower(raw)
+	switch {
+	case strings.Contains(lower, "github.com/") && bd.GitHub == "":
+		if link, err := links.Normalize(links.KindGitHub, "", raw); err == nil {
+			bd.GitHub = link.URL
+			b.score("basicDetails.github", bd.GitHub, confident)
+			return
+		}
+	case strings.Contains(lower, "linkedin.com/") && bd.LinkedIn == "":
+		if link, err := links.Normalize(links.KindLinkedIn, "", raw); err == nil {
+			bd.LinkedIn = link.URL
+			b.score("basicDetails.linkedin", bd.LinkedIn, confident)
+			return
+		}
+	}
+
+	link, err := links.Normalize(links.KindPersonal, "", raw)
+	if err != nil {
+		b.warn("Unrecognized link %q", raw)
+		return
+	}
+	kind := links.KindPersonal
+	for _, known := range []struct{ host, kind string }{
+		{"stackoverflow.com/", links.KindStackOverflow},
+		{"scholar.google.", links.KindScholar},
+		{"orcid.org/", links.KindORCID},
+	} {
+		if strings.Contains(lower, known.host) {
+			kind = known.kind
+		}
+	}
+	if normalized, err := links.Normalize(kind, "", raw); err == nil {
+		link = normalized
+	} else {
+		kind = links.KindPersonal
+	}
+	bd.Links = append(bd.Links, models.ContactLink{Kind: kind, URL: link.URL})
+	b.score(fmt.Sprintf("basicDetails.links.%d.url", len(bd.Links)-1), link.URL, likely)
+}
+
+// readName splits a full name into first and last name. Names printed in
+// capitals, as the resume class does, are title-cased.
+func (b *builder) readName(name string, confidence float64) {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return
+	}
+	if strings.ToUpper(name) == name {
+		for i, w := range words {
+			words[i] = titleCase(w)
+		}
+		confidence = min(confidence, likely)
+	}
+	bd := &b.result.Resume.BasicDetails
+	if len(words) == 1 {
+		bd.FirstName = words[0]
+	} else {
+		bd.FirstName = strings.Join(words[:len(words)-1], " ")
+		bd.LastName = words[len(words)-1]
+	}
+	b.score("basicDetails.firstName", bd.FirstName, confidence)
+	b.score("basicDetails.lastName", bd.LastName, confidence)
+}
+
+// titleCase capitalizes a word, including after hyphens and apostrophes,
+// e.g. "O'BRIEN-SMITH" becomes "O'Brien-Smith"
+func titleCase(w string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range strings.ToLower(w) {
+		if upper {
+			sb.WriteRune(unicode.ToUpper(r))
+		} else {
+			sb.WriteRune(r)
+		}
+		upper = r == '-' || r == '\''
+	}
+	return sb.String()
+}
+
+// splitList splits comma-separated text, dropping a final period and the
+// "and" before the last item
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(strings.TrimSuffix(strings.TrimSpace(s), "."), ",") {
+		if item = strings.TrimPrefix(strings.TrimSpace(item), "and "); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// cutLabel splits "Label: value" when the label is one of the locales'
+// translations of key
+func cutLabel(s, key string) (string, bool) {
+	label, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", false
+	}
+	for _, locale := range i18n.All() {
+		if strings.EqualFold(strings.TrimSpace(label), locale.Labels[key]) {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
+
+// proficiencyLevel maps a proficiency label in any locale back to its level
+func proficiencyLevel(label string) (string, bool) {
+	label = strings.TrimSpace(label)
+	for _, locale := range i18n.All() {
+		for level, l := range locale.Proficiency {
+			if strings.EqualFold(l, label) {
+				return level, true
+			}
+		}
+	}
+	switch strings.ToUpper(label) {
+	case "A1", "A2", "B1", "B2", "C1", "C2":
+		return strings.ToUpper(label), true
+	}
+	return "", false
+}
@@ -0,0 +1,628 @@
+package importer
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// ErrNotResume is returned for LaTeX sources without a name or any resume
+// sections
+var ErrNotResume = errors.New(`no \name or rSection found; only resumes using resume.cls can be imported`)
+
+// texColumn is the position given to cells after the first on a line. LaTeX
+// source has no coordinates, only the order of cells split at \hfill or &.
+const texColumn = 1000
+
+// texSymbols maps commands that print a symbol
+var texSymbols = map[string]string{
+	"textbackslash":   `\`,
+	"textasciitilde":  "~",
+	"textasciicircum": "^",
+	"textbar":         "|",
+	"textbullet":      "•",
+	"bullet":          "•",
+	"diamond":         "⋄",
+	"cdot":            "·",
+	"ldots":           "…",
+	"dots":            "…",
+	"textendash":      "–",
+	"textemdash":      "—",
+	"LaTeX":           "LaTeX",
+	"TeX":             "TeX",
+	"quad":            " ",
+	"qquad":           " ",
+	"newline":         " ",
+	"linebreak":       " ",
+}
+
+// texText are formatting commands whose argument is read as text
+var texText = map[string]bool{
+	"textbf": true, "textit": true, "textsl": true, "textsc": true, "textrm": true,
+	"textsf": true, "texttt": true, "textmd": true, "textup": true, "emph": true,
+	"underline": true, "mbox": true, "text": true, "textnormal": true, "uline": true,
+	"rlap": true, "llap": true, "tab": true, "itab": true,
+}
+
+// texIgnored are commands without printed text. The number is how many
+// arguments they take, which are skipped too.
+var texIgnored = map[string]int{
+	"vspace": 1, "hspace": 1, "atsmark": 1, "phantom": 1,
+	"smallskip": 0, "medskip": 0, "bigskip": 0, "noindent": 0, "par": 0,
+	"hrule": 0, "sectionlineskip": 0, "atsgeometry": 0, "centering": 0,
+	"raggedright": 0, "raggedleft": 0, "hfil": 0, "vfill": 0, "relax": 0,
+	"pagebreak": 0, "newpage": 0, "clearpage": 0, "nopagebreak": 0,
+	"bf": 0, "it": 0, "em": 0, "sc": 0, "rm": 0, "sf": 0, "tt": 0, "sl": 0,
+	"bfseries": 0, "itshape": 0, "scshape": 0, "normalfont": 0,
+	"small": 0, "footnotesize": 0, "large": 0, "Large": 0, "normalsize": 0,
+	"devanagarifont": 0, "leavevmode": 0, "null": 0,
+}
+
+// texDimension matches the length after \itemsep and similar assignments
+var texDimension = regexp.MustCompile(`^\s*=?\s*-?[\d.]+\s*(pt|em|ex|in|cm|mm|bp|sp)\b(\s*(plus|minus)\s*-?[\d.]+\s*(pt|em|ex|in|cm|mm|bp|sp))*`)
+
+// texLengths are assignments followed by a dimension
+var texLengths = map[string]bool{"itemsep": true, "parskip": true, "parsep": true, "topsep": true, "baselineskip": true}
+
+// texReader converts LaTeX source to text, collecting the links it finds
+// and the constructs it does not understand
+type texReader struct {
+	b       *builder
+	unknown map[string]bool
+}
+
+// FromLaTeX reads a resume written with resume.cls: \name and \address in
+// the preamble, and rSection environments with "\textbf{Title} \hfill
+// Dates" headers and itemize bullets in the document, as this service
+// generates them. Constructs it cannot read are listed in the warnings.
+func FromLaTeX(src []byte) (*models.ImportResult, error) {
+	t := &texReader{b: newBuilder(), unknown: make(map[string]bool)}
+	source := stripComments(string(src))
+
+	preamble, body, found := strings.Cut(source, `\begin{document}`)
+	if !found {
+		preamble, body = "", source
+	}
+	body, _, _ = strings.Cut(body, `\end{document}`)
+
+	name, hasName := commandArg(preamble+body, "name")
+	if hasName {
+		t.b.readName(t.plain(name, nil), confident)
+	}
+	var contacts []string
+	rest := preamble
+	for {
+		i := strings.Index(rest, `\address`)
+		if i < 0 {
+			break
+		}
+		arg, end, ok := braced(rest, i+len(`\address`))
+		if !ok {
+			break
+		}
+		contacts = append(contacts, t.headerParts(arg)...)
+		rest = rest[end:]
+	}
+
+	// Everything before the first section, e.g. stacked links printed with
+	// \printaddress, belongs to the header
+	first := strings.Index(body, `\begin{rSection}`)
+	if first < 0 {
+		if !hasName {
+			return nil, ErrNotResume
+		}
+		first = len(body)
+	}
+	for rest := body[:first]; ; {
+		i := strings.Index(rest, `\printaddress`)
+		if i < 0 {
+			t.outside(rest)
+			break
+		}
+		t.outside(rest[:i])
+		arg, end, _ := braced(rest, i+len(`\printaddress`))
+		contacts = append(contacts, t.headerParts(arg)...)
+		rest = rest[end:]
+	}
+	t.b.readContacts(contacts)
+
+	rest = body[first:]
+	for {
+		start := strings.Index(rest, `\begin{rSection}`)
+		if start < 0 {
+			t.outside(rest)
+			break
+		}
+		t.outside(rest[:start])
+		heading, end, _ := braced(rest, start+len(`\begin{rSection}`))
+		length := strings.Index(rest[end:], `\end{rSection}`)
+		if length < 0 {
+			length = len(rest) - end
+			t.b.warn(`The section %q has no \end{rSection}`, heading)
+		}
+		t.section(heading, rest[end:end+length])
+		rest = rest[min(end+length+len(`\end{rSection}`), len(rest)):]
+	}
+
+	if len(t.unknown) > 0 {
+		var names []string
+		for name := range t.unknown {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			t.b.warn(`Unrecognized LaTeX %s was read as plain text`, name)
+		}
+	}
+	return t.b.finish()
+}
+
+// outside warns about text between sections, which has no section to go to
+func (t *texReader) outside(src string) {
+	if text := strings.TrimSpace(t.plain(src, nil)); text != "" {
+		t.b.warn("Text outside of sections was skipped: %q", text)
+	}
+}
+
+// headerParts reads an \address argument into contact details. Links are
+// read from their targets, so "\href{mailto:a@b.c}{Email}" gives a@b.c.
+func (t *texReader) headerParts(src string) []string {
+	var parts []string
+	for _, part := range splitTop(src, `\\`) {
+		var links []link
+		text := t.plain(part, &links)
+		for _, l := range links {
+			target := strings.TrimPrefix(strings.TrimPrefix(l.url, "mailto:"), "tel:")
+			text = strings.Replace(text, l.label, target, 1)
+		}
+		parts = append(parts, text)
+	}
+	return parts
+}
+
+// section reads one rSection into lines and hands them to the section
+// readers shared with the PDF importer
+func (t *texReader) section(heading, src string) {
+	title := strings.TrimSpace(t.plain(heading, nil))
+	sectionType, known := sectionType(title)
+	confidence := confident
+	if known {
+		title = headingTitle(title)
+	} else {
+		sectionType, confidence = "custom", likely
+	}
+
+	r := &sectionReader{b: t.b, lines: t.lines(src)}
+	r.read(sectionType, title, confidence)
+}
+
+// lines splits section source into lines at \\, blank lines, \item and
+// list and table boundaries. Cells are split at \hfill, or at & in tables.
+func (t *texReader) lines(src string) []line {
+	var lines []line
+	var current strings.Builder
+	bullet, lists, table := false, 0, false
+
+	flush := func() {
+		raw := current.String()
+		current.Reset()
+		item := bullet
+		bullet = false
+
+		// resume.cls items outside a list, "\item \textbf{Name.} Description",
+		// are a header with one bullet
+		if item && lists == 0 {
+			if name, end, ok := leadingBold(raw); ok {
+				if header := t.newLine(name, false, false); header.text != "" {
+					header.parts[0] = strings.TrimSuffix(header.parts[0], ".")
+					header.text = strings.TrimSuffix(header.text, ".")
+					lines = append(lines, header)
+				}
+				raw = raw[end:]
+			}
+		}
+		if l := t.newLine(raw, item, table); l.text != "" {
+			lines = append(lines, l)
+		}
+	}
+
+	depth := 0
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		case c == '\n' && depth == 0 && blankLineAt(src, i+1):
+			flush()
+		case c == '\\':
+			name := commandName(src, i+1)
+			if name == "" {
+				if depth == 0 && strings.HasPrefix(src[i:], `\\`) {
+					flush()
+					i += 2
+					if _, end, ok := bracketed(src, i); ok {
+						i = end
+					}
+					continue
+				}
+				// An escaped character, e.g. \{
+				current.WriteString(src[i:min(i+2, len(src))])
+				i += 2
+				continue
+			}
+			if depth > 0 {
+				current.WriteString(`\` + name)
+				i += 1 + len(name)
+				continue
+			}
+			switch name {
+			case "item":
+				flush()
+				bullet = true
+				i += 1 + len(name)
+				if _, end, ok := bracketed(src, i); ok {
+					i = end
+				}
+				continue
+			case "begin", "end":
+				env, end, _ := braced(src, i+1+len(name))
+				switch env {
+				case "itemize", "enumerate", "description":
+					flush()
+					if name == "begin" {
+						lists++
+					} else {
+						lists = max(lists-1, 0)
+					}
+				case "tabular", "tabular*", "tabularx":
+					flush()
+					table = name == "begin"
+					if table {
+						// Skip the column spec, after the width of tabular* and tabularx
+						args := 2
+						if env == "tabular" {
+							args = 1
+						}
+						for range args {
+							_, end, _ = braced(src, end)
+						}
+					}
+				case "rSubsection":
+					flush()
+					if name == "begin" {
+						// {Company}{Dates}{Title}{Location}
+						var args [4]string
+						for k := range args {
+							args[k], end, _ = braced(src, end)
+						}
+						lines = append(lines,
+							t.cells([]string{args[2], args[1]}, false),
+							t.cells([]string{args[0], args[3]}, false))
+						lists++
+					} else {
+						lists = max(lists-1, 0)
+					}
+				default:
+					t.unknown["environment "+env] = true
+				}
+				i = end
+				continue
+			}
+		}
+		current.WriteByte(c)
+		i++
+	}
+	flush()
+	return lines
+}
+
+// newLine converts a line of source to a line of text
+func (t *texReader) newLine(raw string, bullet, table bool) line {
+	separator := `\hfill`
+	if table {
+		separator = "&"
+	}
+	l := t.cells(splitTop(raw, separator), bullet)
+	if table && len(l.parts) == 1 && len(splitTop(raw, separator)) > 1 {
+		// A row with an empty cell keeps its column
+		l.xs[0] = texColumn
+	}
+	return l
+}
+
+// cells builds a line from the source of its cells, dropping empty ones
+func (t *texReader) cells(cells []string, bullet bool) line {
+	l := line{bullet: bullet}
+	for _, cell := range cells {
+		var links []link
+		text := strings.TrimSpace(t.plain(cell, &links))
+		if text == "" {
+			continue
+		}
+		x := 0.0
+		if len(l.parts) > 0 {
+			x = texColumn
+		}
+		l.parts = append(l.parts, text)
+		l.xs = append(l.xs, x)
+		l.links = append(l.links, links...)
+	}
+	l.text = strings.Join(l.parts, " ")
+	return l
+}
+
+// plain converts LaTeX source to text. Links are appended to links when it
+// is not nil.
+func (t *texReader) plain(src string, links *[]link) string {
+	var sb strings.Builder
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\\':
+			i++
+			if i >= len(src) {
+				break
+			}
+			name := commandName(src, i)
+			if name == "" {
+				// Escaped character, e.g. \% or \&; "\\" and "\ " are spaces
+				switch src[i] {
+				case '\\', ' ', '\n':
+					sb.WriteByte(' ')
+				case '-', '/':
+				default:
+					sb.WriteByte(src[i])
+				}
+				i++
+				continue
+			}
+			i += len(name)
+			i = t.command(name, src, i, &sb, links)
+		case c == '{' || c == '}' || c == '$':
+			i++
+		case c == '~':
+			sb.WriteByte(' ')
+			i++
+		case strings.HasPrefix(src[i:], "---"):
+			sb.WriteString("—")
+			i += 3
+		case strings.HasPrefix(src[i:], "--"):
+			sb.WriteString("–")
+			i += 2
+		case strings.HasPrefix(src[i:], "``") || strings.HasPrefix(src[i:], "''"):
+			sb.WriteByte('"')
+			i += 2
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// command writes the text of a command whose name ends at i and returns the
+// index after its arguments
+func (t *texReader) command(name, src string, i int, sb *strings.Builder, links *[]link) int {
+	if symbol, ok := texSymbols[name]; ok {
+		sb.WriteString(symbol)
+		return skipSpaceAfterWord(src, i)
+	}
+	if texText[name] {
+		arg, end, ok := braced(src, i)
+		if !ok {
+			return i
+		}
+		sb.WriteString(t.plain(arg, links))
+		return end
+	}
+	if texLengths[name] {
+		return i + len(texDimension.FindString(src[i:]))
+	}
+	if n, ok := texIgnored[name]; ok {
+		if n == 0 {
+			return skipSpaceAfterWord(src, i)
+		}
+		if _, end, ok := bracketed(src, i); ok {
+			i = end
+		}
+		for range n {
+			if _, end, ok := braced(src, i); ok {
+				i = end
+			}
+		}
+		return i
+	}
+
+	switch name {
+	case "href":
+		url, end, ok := braced(src, i)
+		if !ok {
+			return i
+		}
+		label, end, _ := braced(src, end)
+		text := t.plain(label, links)
+		if links != nil {
+			*links = append(*links, link{url: strings.NewReplacer(`\%`, "%", `\#`, "#", `\&`, "&", `\_`, "_").Replace(url), label: text})
+		}
+		sb.WriteString(text)
+		return end
+	case "url":
+		url, end, ok := braced(src, i)
+		if !ok {
+			return i
+		}
+		sb.WriteString(url)
+		return end
+	case "begin", "end":
+		env, end, _ := braced(src, i)
+		if name == "begin" && env != "itemize" && env != "enumerate" {
+			t.unknown["environment "+env] = true
+		}
+		return end
+	}
+
+	t.unknown[`command \`+name] = true
+	if _, end, ok := bracketed(src, i); ok {
+		return end
+	}
+	return i
+}
+
+// link is a hyperlink found in LaTeX source
+type link struct {
+	url, label string
+}
+
+// stripComments removes % comments, keeping escaped \%
+func stripComments(src string) string {
+	var sb strings.Builder
+	for _, l := range strings.Split(src, "\n") {
+		for i := 0; i < len(l); i++ {
+			if l[i] == '\\' {
+				i++
+				continue
+			}
+			if l[i] == '%' {
+				l = l[:i]
+				break
+			}
+		}
+		sb.WriteString(l)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// commandName returns the letters of a command name starting at i
+func commandName(src string, i int) string {
+	end := i
+	for end < len(src) && (src[end] >= 'a' && src[end] <= 'z' || src[end] >= 'A' && src[end] <= 'Z' || src[end] == '@') {
+		end++
+	}
+	if end < len(src) && src[end] == '*' && end > i {
+		end++
+	}
+	return src[i:end]
+}
+
+// skipSpaceAfterWord skips the spaces TeX swallows after a command word
+func skipSpaceAfterWord(src string, i int) int {
+	for i < len(src) && (src[i] == ' ' || src[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+// braced returns the content of the brace group at or after i, skipping
+// whitespace, and the index after it
+func braced(src string, i int) (string, int, bool) {
+	return group(src, i, '{', '}')
+}
+
+// bracketed returns the content of an optional [argument] at or after i
+func bracketed(src string, i int) (string, int, bool) {
+	return group(src, i, '[', ']')
+}
+
+func group(src string, i int, open, close byte) (string, int, bool) {
+	for i < len(src) && unicode.IsSpace(rune(src[i])) {
+		i++
+	}
+	if i >= len(src) || src[i] != open {
+		return "", i, false
+	}
+	depth := 0
+	for j := i; j < len(src); j++ {
+		switch {
+		case src[j] == '\\':
+			j++
+		case src[j] == open:
+			depth++
+		case src[j] == close:
+			depth--
+			if depth == 0 {
+				return src[i+1 : j], j + 1, true
+			}
+		}
+	}
+	return src[i+1:], len(src), true
+}
+
+// commandArg returns the first argument of the first use of a command
+func commandArg(src, name string) (string, bool) {
+	for i := 0; ; {
+		j := strings.Index(src[i:], `\`+name)
+		if j < 0 {
+			return "", false
+		}
+		i += j + 1 + len(name)
+		if commandName(src, i-len(name)) != name {
+			continue
+		}
+		if arg, _, ok := braced(src, i); ok {
+			return arg, true
+		}
+	}
+}
+
+// splitTop splits source at a separator outside brace groups. A command
+// separator such as \hfill must not be the start of a longer name.
+func splitTop(src, separator string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(src); i++ {
+		if depth == 0 && strings.HasPrefix(src[i:], separator) &&
+			(separator[0] != '\\' || separator == `\\` || commandName(src, i+1) == separator[1:]) {
+			parts = append(parts, src[start:i])
+			i += len(separator) - 1
+			start = i + 1
+			continue
+		}
+		switch src[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+	}
+	return append(parts, src[start:])
+}
+
+// blankLineAt reports whether src starts with a line holding only spaces,
+// which ends a paragraph
+func blankLineAt(src string, i int) bool {
+	for ; i < len(src); i++ {
+		switch src[i] {
+		case ' ', '\t', '\r':
+		case '\n':
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// leadingBold returns the argument of a \textbf or {\bf ...} group that
+// starts the source, and the index after it
+func leadingBold(src string) (string, int, bool) {
+	trimmed := strings.TrimLeftFunc(src, unicode.IsSpace)
+	offset := len(src) - len(trimmed)
+	switch {
+	case strings.HasPrefix(trimmed, `\textbf`):
+		arg, end, ok := braced(trimmed, len(`\textbf`))
+		return arg, offset + end, ok
+	case strings.HasPrefix(trimmed, `{\bf `), strings.HasPrefix(trimmed, `{\bfseries `):
+		arg, end, ok := braced(trimmed, 0)
+		return arg, offset + end, ok
+	}
+	return "", 0, false
+}
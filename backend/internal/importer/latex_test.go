@@ -0,0 +1,166 @@
+package importer
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// section decodes the content of the first section of a type
+func section(t *testing.T, result *models.ImportResult, sectionType string, v interface{}) {
+	t.Helper()
+	for _, s := range result.Resume.Sections {
+		if s.Type == sectionType {
+			if err := models.DecodeContent(s.Content, v); err != nil {
+				t.Fatalf("%s content: %v", sectionType, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("no %s section in %+v", sectionType, result.Resume.Sections)
+}
+
+func TestFromLaTeX(t *testing.T) {
+	src, err := os.ReadFile("../../../res template/resume_faangpath.tex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := FromLaTeX(src)
+	if err != nil {
+		t.Fatalf("FromLaTeX error = %v", err)
+	}
+
+	bd := result.Resume.BasicDetails
+	if bd.FirstName != "Firstname" || bd.LastName != "Lastname" || bd.Email != "contact@faangpath.com" || bd.Phone != "+11234567890" {
+		t.Errorf("basic details = %+v", bd)
+	}
+	if bd.LinkedIn != "https://linkedin.com/company/faangpath" || len(bd.Links) != 1 || bd.Links[0].URL != "https://www.faangpath.com" {
+		t.Errorf("links = %q, %+v", bd.LinkedIn, bd.Links)
+	}
+
+	var types []string
+	for _, s := range result.Resume.Sections {
+		types = append(types, s.Type)
+	}
+	if want := "profile_summary education tech_skills experience projects custom custom"; strings.Join(types, " ") != want {
+		t.Errorf("sections = %s, want %s", strings.Join(types, " "), want)
+	}
+
+	var education models.EducationContent
+	section(t, result, "education", &education)
+	if len(education.Entries) != 2 {
+		t.Fatalf("education entries = %+v", education.Entries)
+	}
+	if e := education.Entries[1]; e.Degree != "Bachelor of Computer Science" || e.Institution != "Stanford University" || e.StartDate != "2014" || e.EndDate != "2017" {
+		t.Errorf("education entry = %+v", e)
+	}
+
+	var experience models.ExperienceContent
+	section(t, result, "experience", &experience)
+	if len(experience.Entries) != 2 {
+		t.Fatalf("experience entries = %+v", experience.Entries)
+	}
+	job := experience.Entries[0]
+	if job.Title != "Role Name" || job.Company != "Company Name" || job.Location != "San Francisco, CA" || job.StartDate != "Jan 2017" || job.EndDate != "Jan 2019" {
+		t.Errorf("experience entry = %+v", job)
+	}
+	if len(job.Bullets) != 3 || job.Bullets[0] != "Achieved X% growth for XYZ using A, B, and C skills." {
+		t.Errorf("bullets = %q", job.Bullets)
+	}
+
+	var skills models.TechSkillsContent
+	section(t, result, "tech_skills", &skills)
+	if len(skills.Categories) != 3 || skills.Categories[0].Name != "Technical Skills" || skills.Categories[0].Skills != "A, B, C, D" {
+		t.Errorf("skills = %+v", skills.Categories)
+	}
+}
+
+func TestFromLaTeXSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		sections string   // Section types, in order
+		warnings []string // Substrings of the expected warnings
+	}{
+		{
+			name: "comments and tables",
+			src: `\name{Jane Doe} % the name
+\begin{document}
+\begin{rSection}{Skills}
+\begin{tabular}{ l l }
+Languages & Go, C\# \\
+\end{tabular}
+\end{rSection}
+\end{document}`,
+			sections: "tech_skills",
+		},
+		{
+			name: "unknown commands are read as text",
+			src: `\name{Jane Doe}
+\begin{document}
+\begin{rSection}{Experience}
+\textbf{Engineer} \hfill 2020 -- 2021\\
+Acme \hfill Remote
+\begin{itemize}
+\item \fancybox{Built} the billing service
+\end{itemize}
+\end{rSection}
+\end{document}`,
+			sections: "experience",
+			warnings: []string{"fancybox"},
+		},
+		{
+			name: "unterminated section",
+			src: `\name{Jane Doe}
+\begin{document}
+\begin{rSection}{Education}
+\textbf{BSc}, State University \hfill 2017`,
+			sections: "education",
+			warnings: []string{`"Education" has no \end{rSection}`},
+		},
+		{
+			name:     "name only",
+			src:      `\name{Jane Doe}`,
+			sections: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FromLaTeX([]byte(tt.src))
+			if err != nil {
+				t.Fatalf("FromLaTeX error = %v", err)
+			}
+			if bd := result.Resume.BasicDetails; bd.FirstName != "Jane" || bd.LastName != "Doe" {
+				t.Errorf("name = %q %q", bd.FirstName, bd.LastName)
+			}
+			var types []string
+			for _, s := range result.Resume.Sections {
+				types = append(types, s.Type)
+			}
+			if got := strings.Join(types, " "); got != tt.sections {
+				t.Errorf("sections = %q, want %q", got, tt.sections)
+			}
+			for _, want := range tt.warnings {
+				found := false
+				for _, w := range result.Warnings {
+					found = found || strings.Contains(w, want)
+				}
+				if !found {
+					t.Errorf("warnings = %q, want one containing %q", result.Warnings, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFromLaTeXRejects(t *testing.T) {
+	for _, src := range []string{"", `\documentclass{article}\begin{document}Hello\end{document}`} {
+		if _, err := FromLaTeX([]byte(src)); !errors.Is(err, ErrNotResume) {
+			t.Errorf("FromLaTeX(%q) error = %v, want ErrNotResume", src, err)
+		}
+	}
+}
@@ -0,0 +1,581 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/links"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// ErrNotLinkedIn is returned for archives without any of the CSVs of a
+// LinkedIn data export
+var ErrNotLinkedIn = errors.New("the archive is not a LinkedIn data export")
+
+// Archive limits. A small ZIP can hold far more uncompressed data, so each
+// CSV and the archive as a whole are bounded, as are the number of entries
+// and the rows read from each table.
+const (
+	maxCSVBytes       = 5 << 20
+	maxCSVRows        = 1000
+	maxArchiveBytes   = 20 << 20
+	maxArchiveEntries = 1000
+)
+
+// linkedInTables lists the tables the importer reads, keyed by lower-case
+// file name without spaces, e.g. "emailaddresses" for "Email Addresses.csv"
+var linkedInTables = map[string]bool{
+	"profile": true, "emailaddresses": true, "phonenumbers": true,
+	"positions": true, "education": true, "skills": true, "projects": true,
+	"volunteering": true, "volunteerexperiences": true, "certifications": true,
+	"honors": true, "publications": true, "languages": true,
+}
+
+// record is a CSV row keyed by column name
+type record map[string]string
+
+// get returns the first non-blank value among the columns; LinkedIn has
+// renamed some of them between export versions
+func (r record) get(columns ...string) string {
+	for _, c := range columns {
+		if v := strings.TrimSpace(r[c]); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// linkedInProficiency maps LinkedIn's language proficiency labels
+var linkedInProficiency = map[string]string{
+	"native or bilingual proficiency":  "native",
+	"full professional proficiency":    "fluent",
+	"professional working proficiency": "professional",
+	"limited working proficiency":      "intermediate",
+	"elementary proficiency":           "basic",
+}
+
+// FromLinkedIn reads a resume from the ZIP archive of a LinkedIn data
+// export. Profile.csv, Positions.csv, Education.csv, Skills.csv and
+// Projects.csv are read, along with contact details, certifications,
+// languages, honors, publications and volunteering when present.
+func FromLinkedIn(data []byte) (*models.ImportResult, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a ZIP archive: %w", err)
+	}
+
+	if len(zr.File) > maxArchiveEntries {
+		return nil, fmt.Errorf("the archive has more than %d entries", maxArchiveEntries)
+	}
+
+	b := newBuilder()
+
+	// Only the tables the importer reads are decompressed, each once, within
+	// the archive's byte budget
+	tables := make(map[string][]record)
+	budget := int64(maxArchiveBytes)
+	for _, f := range zr.File {
+		name := strings.ToLower(path.Base(f.Name))
+		if f.FileInfo().IsDir() || !strings.HasSuffix(name, ".csv") {
+			continue
+		}
+		key := strings.NewReplacer(" ", "", "_", "").Replace(strings.TrimSuffix(name, ".csv"))
+		if !linkedInTables[key] {
+			continue
+		}
+		if _, ok := tables[key]; ok {
+			continue
+		}
+		rows, n, err := b.readCSV(f, min(budget, maxCSVBytes))
+		budget -= n
+		if err != nil {
+			b.warn("Could not read %s: %v", path.Base(f.Name), err)
+			continue
+		}
+		tables[key] = rows
+	}
+
+	known := false
+	for _, name := range []string{"profile", "positions", "education", "skills", "projects"} {
+		_, ok := tables[name]
+		known = known || ok
+	}
+	if !known {
+		return nil, ErrNotLinkedIn
+	}
+
+	b.linkedInProfile(tables)
+	b.linkedInSkills(tables["skills"])
+	b.linkedInPositions(tables["positions"])
+	b.linkedInProjects(tables["projects"])
+	b.linkedInVolunteering(append(tables["volunteering"], tables["volunteerexperiences"]...))
+	b.linkedInEducation(tables["education"])
+	b.linkedInCertifications(tables["certifications"])
+	b.linkedInHonors(tables["honors"])
+	b.linkedInPublications(tables["publications"])
+	b.linkedInLanguages(tables["languages"])
+
+	return b.finish()
+}
+
+// readCSV reads a CSV file of at most limit bytes from the archive into
+// records, reporting the bytes it decompressed. Leading notes, which some
+// exports put above the header, are skipped, and rows past maxCSVRows are
+// dropped with a warning.
+func (b *builder) readCSV(f *zip.File, limit int64) ([]record, int64, error) {
+	if limit <= 0 {
+		return nil, 0, fmt.Errorf("past the archive's %d MB uncompressed limit", maxArchiveBytes>>20)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	n := int64(len(data))
+	if err != nil {
+		return nil, n, err
+	}
+	if n > limit {
+		return nil, n, fmt.Errorf("larger than %d MB, or past the archive's %d MB uncompressed limit", maxCSVBytes>>20, maxArchiveBytes>>20)
+	}
+
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	var header []string
+	var records []record
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, n, err
+		}
+		if len(records) == maxCSVRows {
+			b.warn("Only the first %d rows of %s were read", maxCSVRows, path.Base(f.Name))
+			break
+		}
+		if header == nil {
+			if len(row) == 0 || strings.HasPrefix(row[0], "Notes:") || strings.Join(row, "") == "" {
+				continue
+			}
+			header = row
+			continue
+		}
+		rec := make(record, len(header))
+		for i, column := range header {
+			if i < len(row) {
+				rec[strings.TrimSpace(column)] = row[i]
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, n, nil
+}
+
+// linkedInDate normalizes an export date such as "Jan 2020", "2020" or
+// "Mar 12, 2021" to the canonical form
+func (b *builder) linkedInDate(s string) (string, float64) {
+	if s == "" {
+		return "", 0
+	}
+	if d, ok := parseDate(s); ok {
+		return d, confident
+	}
+	for _, layout := range []string{"Jan 2, 2006", "1/2/06", "1/2/2006", "01/2006", time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			if d, ok := parseDate(t.Format("Jan 2006")); ok {
+				return d, confident
+			}
+		}
+	}
+	b.warn("Unrecognized date %q", s)
+	return s, guessed
+}
+
+// rangeConfidence combines the confidence of a range's dates, ignoring a
+// missing one
+func rangeConfidence(start, end float64) float64 {
+	switch {
+	case start == 0:
+		return end
+	case end == 0:
+		return start
+	}
+	return min(start, end)
+}
+
+// descriptionBullets splits a LinkedIn description into bullets, one per
+// line, without the bullet glyphs users type
+func descriptionBullets(s string) []string {
+	bullets := []string{}
+	for _, l := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		l = strings.TrimSpace(l)
+		if r := []rune(l); len(r) > 1 && strings.ContainsRune(bulletGlyphs, r[0]) {
+			l = strings.TrimSpace(string(r[1:]))
+		}
+		if l != "" {
+			bullets = append(bullets, l)
+		}
+	}
+	return bullets
+}
+
+func (b *builder) linkedInProfile(tables map[string][]record) {
+	bd := &b.result.Resume.BasicDetails
+	if profile := tables["profile"]; len(profile) > 0 {
+		p := profile[0]
+		bd.FirstName, bd.LastName = p.get("First Name"), p.get("Last Name")
+		b.score("basicDetails.firstName", bd.FirstName, confident)
+		b.score("basicDetails.lastName", bd.LastName, confident)
+
+		// "Toronto, Ontario, Canada"
+		if place := strings.Split(p.get("Geo Location", "Location"), ","); len(place) >= 2 {
+			bd.City, bd.Province = strings.TrimSpace(place[0]), strings.TrimSpace(place[1])
+			b.score("basicDetails.city", bd.City, likely)
+			b.score("basicDetails.province", bd.Province, likely)
+		}
+
+		// "[PERSONAL:https://jane.dev,COMPANY:https://acme.com]"
+		for _, site := range strings.Split(strings.Trim(p.get("Websites"), "[]"), ",") {
+			if kind, url, ok := strings.Cut(strings.TrimSpace(site), ":"); ok && !strings.HasPrefix(url, "//") && strings.ToUpper(kind) == kind {
+				site = url
+			}
+			if site = strings.TrimSpace(site); site != "" {
+				b.readLink(bd, site)
+			}
+		}
+
+		if summary := p.get("Summary"); summary != "" {
+			content := models.ProfileSummaryContent{Format: "paragraph", Text: strings.Join(strings.Fields(summary), " ")}
+			path := b.addSection("profile_summary", "", content, confident)
+			b.score(path+".content.text", content.Text, confident)
+		}
+	}
+
+	for _, e := range tables["emailaddresses"] {
+		if email := e.get("Email Address"); email != "" && (bd.Email == "" || strings.EqualFold(e.get("Primary"), "yes")) {
+			bd.Email = email
+			b.score("basicDetails.email", bd.Email, confident)
+		}
+	}
+	for _, p := range tables["phonenumbers"] {
+		if number := p.get("Number"); number != "" && bd.Phone == "" {
+			b.readContact(bd, number)
+		}
+	}
+}
+
+func (b *builder) linkedInSkills(rows []record) {
+	var names []string
+	for _, r := range rows {
+		if name := r.get("Name"); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	content := models.TechSkillsContent{Categories: []models.SkillCategory{{Name: "Skills", Skills: strings.Join(names, ", ")}}}
+	path := b.addSection("tech_skills", "", content, confident)
+	b.score(path+".content.categories.0.name", "Skills", guessed)
+	b.score(path+".content.categories.0.skills", content.Categories[0].Skills, confident)
+}
+
+// linkedInPositions reads positions, newest first as exported. Consecutive
+// positions at the same company are grouped under one entry.
+func (b *builder) linkedInPositions(rows []record) {
+	if len(rows) == 0 {
+		return
+	}
+
+	type scored struct {
+		position       models.ExperiencePosition
+		dateConfidence float64
+	}
+	var entries []models.ExperienceEntry
+	var groups [][]scored
+	for _, r := range rows {
+		start, startConfidence := b.linkedInDate(r.get("Started On"))
+		end, endConfidence := b.linkedInDate(r.get("Finished On"))
+		p := scored{
+			position: models.ExperiencePosition{
+				Title:     r.get("Title"),
+				StartDate: start,
+				EndDate:   end,
+				Current:   end == "",
+				Bullets:   descriptionBullets(r.get("Description")),
+			},
+			dateConfidence: rangeConfidence(startConfidence, endConfidence),
+		}
+
+		company := r.get("Company Name")
+		if n := len(entries); n > 0 && strings.EqualFold(entries[n-1].Company, company) {
+			groups[n-1] = append(groups[n-1], p)
+			continue
+		}
+		entries = append(entries, models.ExperienceEntry{Company: company, Location: r.get("Location")})
+		groups = append(groups, []scored{p})
+	}
+
+	scores := make([]map[string]float64, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		scores[i] = map[string]float64{"company": confident, "location": confident}
+		if len(groups[i]) == 1 {
+			p := groups[i][0]
+			e.Title, e.StartDate, e.EndDate, e.Current, e.Bullets = p.position.Title, p.position.StartDate, p.position.EndDate, p.position.Current, p.position.Bullets
+			scores[i]["title"], scores[i]["startDate"], scores[i]["endDate"] = confident, p.dateConfidence, p.dateConfidence
+			continue
+		}
+		e.Bullets = []string{}
+		for j, p := range groups[i] {
+			e.Positions = append(e.Positions, p.position)
+			prefix := fmt.Sprintf("positions.%d.", j)
+			scores[i][prefix+"title"], scores[i][prefix+"startDate"], scores[i][prefix+"endDate"] = confident, p.dateConfidence, p.dateConfidence
+		}
+	}
+
+	path := b.addSection("experience", "", models.ExperienceContent{Entries: entries}, confident)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		b.score(p+".company", e.Company, scores[i]["company"])
+		b.score(p+".location", e.Location, scores[i]["location"])
+		b.scorePosition(p, models.ExperiencePosition{Title: e.Title, StartDate: e.StartDate, EndDate: e.EndDate, Bullets: e.Bullets}, scores[i]["startDate"])
+		for j, position := range e.Positions {
+			b.scorePosition(fmt.Sprintf("%s.positions.%d", p, j), position, scores[i][fmt.Sprintf("positions.%d.startDate", j)])
+		}
+	}
+}
+
+// scorePosition records the confidence of a position's fields. Bullets split
+// from a free-text description are likely rather than certain.
+func (b *builder) scorePosition(path string, p models.ExperiencePosition, dateConfidence float64) {
+	b.score(path+".title", p.Title, confident)
+	b.score(path+".startDate", p.StartDate, dateConfidence)
+	b.score(path+".endDate", p.EndDate, dateConfidence)
+	for i, bullet := range p.Bullets {
+		b.score(fmt.Sprintf("%s.bullets.%d", path, i), bullet, likely)
+	}
+}
+
+func (b *builder) linkedInProjects(rows []record) {
+	if len(rows) == 0 {
+		return
+	}
+
+	entries := make([]models.ProjectEntry, len(rows))
+	confidences := make([]float64, len(rows))
+	for i, r := range rows {
+		e := &entries[i]
+		e.Name = r.get("Title")
+		e.Description = descriptionBullets(r.get("Description"))
+		e.Date, confidences[i] = b.linkedInDate(r.get("Finished On", "Started On"))
+		if url := r.get("Url"); url != "" {
+			kind := "other"
+			if strings.Contains(strings.ToLower(url), "github.com/") {
+				kind = "repo"
+			}
+			e.Links = []models.ProjectLink{{Kind: kind, URL: url}}
+		}
+	}
+
+	path := b.addSection("projects", "", models.ProjectsContent{Entries: entries}, confident)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		b.score(p+".name", e.Name, confident)
+		b.score(p+".date", e.Date, confidences[i])
+		for j, d := range e.Description {
+			b.score(fmt.Sprintf("%s.description.%d", p, j), d, likely)
+		}
+		for j, l := range e.Links {
+			b.score(fmt.Sprintf("%s.links.%d.url", p, j), l.URL, confident)
+		}
+	}
+}
+
+func (b *builder) linkedInVolunteering(rows []record) {
+	if len(rows) == 0 {
+		return
+	}
+
+	entries := make([]models.VolunteerEntry, len(rows))
+	confidences := make([]float64, len(rows))
+	for i, r := range rows {
+		start, startConfidence := b.linkedInDate(r.get("Started On"))
+		end, endConfidence := b.linkedInDate(r.get("Finished On"))
+		entries[i] = models.VolunteerEntry{
+			Organization: r.get("Company Name", "Organization"),
+			Title:        r.get("Role"),
+			StartDate:    start,
+			EndDate:      end,
+			Current:      start != "" && end == "",
+			Bullets:      descriptionBullets(r.get("Description")),
+		}
+		confidences[i] = rangeConfidence(startConfidence, endConfidence)
+	}
+
+	path := b.addSection("volunteer", "", models.VolunteerContent{Entries: entries}, confident)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		b.score(p+".organization", e.Organization, confident)
+		b.scorePosition(p, models.ExperiencePosition{Title: e.Title, StartDate: e.StartDate, EndDate: e.EndDate, Bullets: e.Bullets}, confidences[i])
+	}
+}
+
+func (b *builder) linkedInEducation(rows []record) {
+	if len(rows) == 0 {
+		return
+	}
+
+	entries := make([]models.EducationEntry, len(rows))
+	confidences := make([]float64, len(rows))
+	for i, r := range rows {
+		start, startConfidence := b.linkedInDate(r.get("Start Date", "Started On"))
+		end, endConfidence := b.linkedInDate(r.get("End Date", "Finished On"))
+		entries[i] = models.EducationEntry{
+			Institution: r.get("School Name"),
+			Degree:      r.get("Degree Name"),
+			StartDate:   start,
+			EndDate:     end,
+		}
+		confidences[i] = rangeConfidence(startConfidence, endConfidence)
+	}
+
+	path := b.addSection("education", "", models.EducationContent{Entries: entries}, confident)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		b.score(p+".institution", e.Institution, confident)
+		b.score(p+".degree", e.Degree, confident)
+		b.score(p+".startDate", e.StartDate, confidences[i])
+		b.score(p+".endDate", e.EndDate, confidences[i])
+	}
+}
+
+func (b *builder) linkedInCertifications(rows []record) {
+	if len(rows) == 0 {
+		return
+	}
+
+	entries := make([]models.CertificationEntry, len(rows))
+	confidences := make([]float64, len(rows))
+	for i, r := range rows {
+		date, dateConfidence := b.linkedInDate(r.get("Started On"))
+		expiry, expiryConfidence := b.linkedInDate(r.get("Finished On"))
+		entries[i] = models.CertificationEntry{
+			Name:         r.get("Name"),
+			Issuer:       r.get("Authority"),
+			Date:         date,
+			ExpiryDate:   expiry,
+			CredentialID: r.get("License Number"),
+			URL:          r.get("Url"),
+		}
+		confidences[i] = rangeConfidence(dateConfidence, expiryConfidence)
+	}
+
+	path := b.addSection("certifications", "", models.CertificationsContent{Entries: entries}, confident)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		for field, value := range map[string]string{"name": e.Name, "issuer": e.Issuer, "credentialId": e.CredentialID, "url": e.URL} {
+			b.score(p+"."+field, value, confident)
+		}
+		b.score(p+".date", e.Date, confidences[i])
+		b.score(p+".expiryDate", e.ExpiryDate, confidences[i])
+	}
+}
+
+func (b *builder) linkedInHonors(rows []record) {
+	if len(rows) == 0 {
+		return
+	}
+
+	entries := make([]models.AwardEntry, len(rows))
+	confidences := make([]float64, len(rows))
+	for i, r := range rows {
+		entries[i] = models.AwardEntry{
+			Title:       r.get("Title"),
+			Issuer:      r.get("Issuer"),
+			Description: strings.Join(strings.Fields(r.get("Description")), " "),
+		}
+		entries[i].Date, confidences[i] = b.linkedInDate(r.get("Issued On"))
+	}
+
+	path := b.addSection("awards", "", models.AwardsContent{Entries: entries}, confident)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		b.score(p+".title", e.Title, confident)
+		b.score(p+".issuer", e.Issuer, confident)
+		b.score(p+".description", e.Description, confident)
+		b.score(p+".date", e.Date, confidences[i])
+	}
+}
+
+func (b *builder) linkedInPublications(rows []record) {
+	if len(rows) == 0 {
+		return
+	}
+
+	entries := make([]models.PublicationEntry, len(rows))
+	for i, r := range rows {
+		e := &entries[i]
+		e.Title, e.Venue = r.get("Name"), r.get("Publisher")
+		if date, _ := b.linkedInDate(r.get("Published On")); len(date) >= 4 {
+			if year := date[len(date)-4:]; yearOnly(year) {
+				e.Year = year
+			}
+		}
+		url := r.get("Url")
+		if doi, err := links.NormalizeDOI(url); err == nil && strings.Contains(strings.ToLower(url), "doi") {
+			e.DOI = doi
+		} else {
+			e.URL = url
+		}
+	}
+
+	path := b.addSection("publications", "", models.PublicationsContent{Entries: entries}, confident)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		for field, value := range map[string]string{"title": e.Title, "venue": e.Venue, "year": e.Year, "doi": e.DOI, "url": e.URL} {
+			b.score(p+"."+field, value, confident)
+		}
+	}
+}
+
+func (b *builder) linkedInLanguages(rows []record) {
+	if len(rows) == 0 {
+		return
+	}
+
+	entries := make([]models.LanguageEntry, len(rows))
+	for i, r := range rows {
+		entries[i].Name = r.get("Name")
+		if label := r.get("Proficiency"); label != "" {
+			if level, ok := linkedInProficiency[strings.ToLower(label)]; ok {
+				entries[i].Proficiency = level
+			} else if level, ok := proficiencyLevel(label); ok {
+				entries[i].Proficiency = level
+			} else {
+				b.warn("Unrecognized proficiency %q for %s", label, entries[i].Name)
+			}
+		}
+	}
+
+	path := b.addSection("languages", "", models.LanguagesContent{Entries: entries}, confident)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		b.score(p+".name", e.Name, confident)
+		b.score(p+".proficiency", e.Proficiency, confident)
+	}
+}
@@ -0,0 +1,167 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// archive builds a ZIP file from names and contents
+func archive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+var linkedInExport = map[string]string{
+	"Profile.csv": "First Name,Last Name,Headline,Summary,Geo Location,Websites\n" +
+		"Jane,Doe,Engineer,\"Backend engineer\nwho ships.\",\"Toronto, Ontario, Canada\",[PERSONAL:https://janedoe.dev]\n",
+	"Email Addresses.csv": "Email Address,Confirmed,Primary\nold@example.com,Yes,No\njane@example.com,Yes,Yes\n",
+	"Positions.csv": "Company Name,Title,Description,Location,Started On,Finished On\n" +
+		"Acme,Staff Engineer,\"• Led the platform team\n• Cut costs 20%\",Toronto,Mar 2022,\n" +
+		"Acme,Senior Engineer,,Toronto,Jan 2020,Feb 2022\n" +
+		"Globex,Developer,Built things,Remote,2017,Dec 2019\n",
+	"Skills.csv":            "Name\nGo\nKubernetes\n",
+	"Education.csv":         "School Name,Start Date,End Date,Notes,Degree Name,Activities\nState University,2013,2017,,BSc Computer Science,\n",
+	"Ad_Targeting.csv":      "Notes:\nignored\n",
+	"messages/messages.csv": "FROM,TO\na,b\n",
+}
+
+func TestFromLinkedIn(t *testing.T) {
+	result, err := FromLinkedIn(archive(t, linkedInExport))
+	if err != nil {
+		t.Fatalf("FromLinkedIn error = %v", err)
+	}
+
+	bd := result.Resume.BasicDetails
+	if bd.FirstName != "Jane" || bd.LastName != "Doe" || bd.Email != "jane@example.com" || bd.City != "Toronto" || bd.Province != "Ontario" {
+		t.Errorf("basic details = %+v", bd)
+	}
+	if len(bd.Links) != 1 || bd.Links[0].URL != "https://janedoe.dev" {
+		t.Errorf("links = %+v", bd.Links)
+	}
+
+	var summary models.ProfileSummaryContent
+	section(t, result, "profile_summary", &summary)
+	if summary.Text != "Backend engineer who ships." {
+		t.Errorf("summary = %q", summary.Text)
+	}
+
+	var experience models.ExperienceContent
+	section(t, result, "experience", &experience)
+	if len(experience.Entries) != 2 {
+		t.Fatalf("experience entries = %+v, want Acme and Globex", experience.Entries)
+	}
+	acme, globex := experience.Entries[0], experience.Entries[1]
+	if acme.Company != "Acme" || len(acme.Positions) != 2 {
+		t.Fatalf("Acme = %+v, want two grouped positions", acme)
+	}
+	staff := acme.Positions[0]
+	if staff.Title != "Staff Engineer" || staff.StartDate != "Mar 2022" || !staff.Current {
+		t.Errorf("current position = %+v", staff)
+	}
+	if want := []string{"Led the platform team", "Cut costs 20%"}; strings.Join(staff.Bullets, "|") != strings.Join(want, "|") {
+		t.Errorf("bullets = %q, want %q", staff.Bullets, want)
+	}
+	if globex.Title != "Developer" || globex.StartDate != "2017" || globex.EndDate != "Dec 2019" || globex.Current {
+		t.Errorf("Globex = %+v", globex)
+	}
+
+	var skills models.TechSkillsContent
+	section(t, result, "tech_skills", &skills)
+	if len(skills.Categories) != 1 || skills.Categories[0].Skills != "Go, Kubernetes" {
+		t.Errorf("skills = %+v", skills.Categories)
+	}
+
+	if c := result.Confidence["basicDetails.email"]; c != confident {
+		t.Errorf("email confidence = %v, want %v", c, confident)
+	}
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "Ad_Targeting") || strings.Contains(w, "messages") {
+			t.Errorf("unused table was read: %s", w)
+		}
+	}
+}
+
+func TestFromLinkedInRejects(t *testing.T) {
+	if _, err := FromLinkedIn([]byte("not a zip")); err == nil {
+		t.Error("FromLinkedIn accepted a non-ZIP upload")
+	}
+
+	other := archive(t, map[string]string{"data.csv": "a,b\n1,2\n"})
+	if _, err := FromLinkedIn(other); !errors.Is(err, ErrNotLinkedIn) {
+		t.Errorf("FromLinkedIn(other archive) error = %v, want ErrNotLinkedIn", err)
+	}
+
+	files := map[string]string{"Profile.csv": "First Name,Last Name\nJane,Doe\n"}
+	for i := 0; i < maxArchiveEntries; i++ {
+		files[fmt.Sprintf("junk/%d.csv", i)] = ""
+	}
+	if _, err := FromLinkedIn(archive(t, files)); err == nil {
+		t.Errorf("FromLinkedIn accepted more than %d entries", maxArchiveEntries)
+	}
+}
+
+func TestFromLinkedInSizeLimits(t *testing.T) {
+	row := "Acme,Engineer,,Toronto,Jan 2020,Feb 2021\n"
+	huge := "Company Name,Title,Description,Location,Started On,Finished On\n" + strings.Repeat(row, maxCSVBytes/len(row)+1)
+	files := map[string]string{
+		"Profile.csv":   "First Name,Last Name\nJane,Doe\n",
+		"Positions.csv": huge,
+	}
+
+	result, err := FromLinkedIn(archive(t, files))
+	if err != nil {
+		t.Fatalf("FromLinkedIn error = %v", err)
+	}
+	if result.Resume.BasicDetails.FirstName != "Jane" {
+		t.Errorf("profile was not read: %+v", result.Resume.BasicDetails)
+	}
+	for _, s := range result.Resume.Sections {
+		if s.Type == "experience" {
+			t.Error("oversized Positions.csv was read")
+		}
+	}
+	if len(result.Warnings) == 0 || !strings.Contains(result.Warnings[0], "Positions.csv") {
+		t.Errorf("warnings = %q, want one for Positions.csv", result.Warnings)
+	}
+}
+
+func TestFromLinkedInArchiveBudget(t *testing.T) {
+	// Each table fits the per-file limit, but together they pass the budget
+	filler := "Name\n" + strings.Repeat("x\n", (maxCSVBytes-1024)/2)
+	files := map[string]string{"Profile.csv": "First Name,Last Name\nJane,Doe\n"}
+	for _, name := range []string{"Skills.csv", "Languages.csv", "Honors.csv", "Publications.csv", "Certifications.csv"} {
+		files[name] = filler
+	}
+
+	result, err := FromLinkedIn(archive(t, files))
+	if err != nil {
+		t.Fatalf("FromLinkedIn error = %v", err)
+	}
+	limited := false
+	for _, w := range result.Warnings {
+		limited = limited || strings.Contains(w, "uncompressed limit")
+	}
+	if !limited {
+		t.Errorf("warnings = %q, want one for the archive budget", result.Warnings)
+	}
+}
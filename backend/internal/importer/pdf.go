@@ -0,0 +1,944 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+	"github.com/sahil/ats-resume-maker/backend/internal/pdftext"
+)
+
+// ErrNoText is returned for PDFs without a text layer, such as scans
+var ErrNoText = errors.New("the PDF has no extractable text")
+
+// indentTolerance is how far, in points, a line may start right of the
+// section's left edge and still begin a new item rather than continue one
+const indentTolerance = 4
+
+// bulletGlyphs mark list items
+const bulletGlyphs = "•◦▪▫‣●○■□➢►▶✓✔–-*·"
+
+// line is a line of PDF text prepared for section detection
+type line struct {
+	parts  []string  // Segments separated by wide gaps, e.g. a title and its dates
+	xs     []float64 // Left edge of each part
+	size   float64
+	text   string
+	bullet bool   // Starts with a bullet glyph, which is removed from the text
+	links  []link // Hyperlinks from LaTeX source or the PDF's link annotations
+}
+
+// x is the left edge of the line
+func (l line) x() float64 {
+	if len(l.xs) == 0 {
+		return 0
+	}
+	return l.xs[0]
+}
+
+// FromPDF reads a resume from the text of a PDF. Headings such as
+// EXPERIENCE or EDUCATION split it into sections; within them, date ranges
+// start entries and bullet glyphs mark list items. The layout written by
+// this service's own template is read most reliably.
+func FromPDF(data []byte) (*models.ImportResult, error) {
+	doc, err := pdftext.Extract(data)
+	if err != nil {
+		return nil, err
+	}
+	return fromDocument(doc)
+}
+
+func fromDocument(doc *pdftext.Document) (*models.ImportResult, error) {
+	var lines []line
+	for _, page := range doc.Pages {
+		for _, l := range page.Lines {
+			// Lines left empty are lone glyphs, e.g. the bullet of an empty item
+			if pl := newLine(l); pl.text != "" {
+				pl.links = linksOn(page.Links, l.Y)
+				lines = append(lines, pl)
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return nil, ErrNoText
+	}
+
+	b := newBuilder()
+
+	// Everything above the first heading is the header
+	first := len(lines)
+	for i, l := range lines {
+		if _, ok := sectionType(l.text); ok && !l.bullet {
+			first = i
+			break
+		}
+	}
+	if first == len(lines) {
+		b.warn("No section headings were recognized")
+	}
+
+	// The name is the largest text in the header, or its first line
+	nameLine := 0
+	for i, l := range lines[:first] {
+		if l.size > lines[nameLine].size+0.5 {
+			nameLine = i
+		}
+	}
+	var contacts []string
+	for i, l := range lines[:first] {
+		if i == nameLine {
+			b.readName(l.text, likely)
+		} else {
+			contacts = append(contacts, l.parts...)
+		}
+	}
+	b.readContacts(contacts)
+
+	// Split the rest into sections at known headings, and at upper-case
+	// lines that look like headings
+	type block struct {
+		sectionType, title string
+		confidence         float64
+		lines              []line
+	}
+	var blocks []block
+	for _, l := range lines[first:] {
+		// A heading naming the current section, e.g. "Technical Skills" in
+		// SKILLS, is a label within it
+		if t, ok := sectionType(l.text); ok && !l.bullet && (len(blocks) == 0 || t != blocks[len(blocks)-1].sectionType) {
+			blocks = append(blocks, block{sectionType: t, title: headingTitle(l.text), confidence: confident})
+			continue
+		}
+		last := &blocks[len(blocks)-1]
+		if !l.bullet && len(l.parts) == 1 && looksLikeHeading(l.text) && len(last.lines) > 0 {
+			blocks = append(blocks, block{sectionType: "custom", title: titleCaseWords(l.text), confidence: guessed})
+			continue
+		}
+		last.lines = append(last.lines, l)
+	}
+
+	for _, blk := range blocks {
+		r := &sectionReader{b: b, lines: blk.lines}
+		if len(blk.lines) > 0 {
+			r.left = blk.lines[0].x()
+			for _, l := range blk.lines {
+				r.left = math.Min(r.left, l.x())
+			}
+		}
+		r.read(blk.sectionType, blk.title, blk.confidence)
+	}
+
+	return b.finish()
+}
+
+func newLine(l pdftext.Line) line {
+	pl := line{size: l.Size}
+	for _, seg := range l.Segments {
+		pl.parts = append(pl.parts, strings.TrimSpace(seg.Text))
+		pl.xs = append(pl.xs, seg.X0)
+	}
+	if len(pl.parts) > 0 {
+		r := []rune(pl.parts[0])
+		if len(r) > 0 && strings.ContainsRune(bulletGlyphs, r[0]) && (len(r) == 1 || unicode.IsSpace(r[1])) {
+			pl.bullet = true
+			pl.parts[0] = strings.TrimSpace(string(r[1:]))
+			if pl.parts[0] == "" {
+				pl.parts, pl.xs = pl.parts[1:], pl.xs[1:]
+			}
+		}
+	}
+	pl.text = strings.Join(pl.parts, " ")
+	return pl
+}
+
+// linksOn returns the web links whose text sits on the baseline y
+func linksOn(links []pdftext.Link, y float64) []link {
+	var found []link
+	for _, l := range links {
+		if l.Text != "" && math.Abs(l.Y-y) <= 2 && !strings.HasPrefix(l.URI, "mailto:") {
+			found = append(found, link{url: l.URI, label: l.Text})
+		}
+	}
+	return found
+}
+
+// headingTitle keeps an alias heading, e.g. "WORK HISTORY", as the section
+// title; built-in headings are left to the renderer
+func headingTitle(heading string) string {
+	if _, alias := headingAliases[strings.ToUpper(strings.Join(strings.Fields(heading), " "))]; !alias {
+		return ""
+	}
+	return titleCaseWords(heading)
+}
+
+func titleCaseWords(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		words[i] = titleCase(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// sectionReader reads the lines of one section
+type sectionReader struct {
+	b     *builder
+	lines []line
+	left  float64 // Left edge of the section's items
+}
+
+func (r *sectionReader) read(sectionType, title string, confidence float64) {
+	switch sectionType {
+	case "profile_summary":
+		r.summary(title, confidence)
+	case "tech_skills":
+		r.skills(title, confidence)
+	case "experience", "volunteer":
+		r.experience(sectionType, title, confidence)
+	case "projects":
+		r.projects(title, confidence)
+	case "education":
+		r.education(title, confidence)
+	case "certifications":
+		r.certifications(title, confidence)
+	case "awards":
+		r.awards(title, confidence)
+	case "publications":
+		r.publications(title, confidence)
+	case "languages":
+		r.languages(title, confidence)
+	case "interests":
+		r.interests(title, confidence)
+	default:
+		r.custom(title, confidence)
+	}
+}
+
+// continues reports whether a line wraps the previous item: it is indented
+// past the section's left edge and carries no marker of its own
+func (r *sectionReader) continues(l line) bool {
+	return !l.bullet && l.x() > r.left+indentTolerance
+}
+
+// bullets collects the bullet items starting at lines[i], joining wrapped
+// lines, and returns the index after them
+func (r *sectionReader) bullets(i int) ([]string, int) {
+	var items []string
+	for ; i < len(r.lines); i++ {
+		l := r.lines[i]
+		switch {
+		case l.bullet:
+			items = append(items, l.text)
+		case len(items) > 0 && r.continues(l):
+			items[len(items)-1] = joinWrapped(items[len(items)-1], l.text)
+		default:
+			return items, i
+		}
+	}
+	return items, i
+}
+
+// paragraph joins lines into running text
+func paragraph(lines []line) string {
+	var text string
+	for _, l := range lines {
+		text = joinWrapped(text, l.text)
+	}
+	return text
+}
+
+// joinWrapped appends a wrapped line, rejoining words hyphenated across the
+// break
+func joinWrapped(text, next string) string {
+	switch {
+	case text == "":
+		return next
+	case next == "":
+		return text
+	case strings.HasSuffix(text, "-") && startsLower(next):
+		return strings.TrimSuffix(text, "-") + next
+	default:
+		return text + " " + next
+	}
+}
+
+func startsLower(s string) bool {
+	for _, r := range s {
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// dated splits the dates off a line, preferring a right-aligned segment,
+// and reports how confident the split is
+func dated(l line) (text, start, end string, confidence float64, ok bool) {
+	if len(l.parts) > 1 {
+		if start, end, ok := dateRange(l.parts[len(l.parts)-1]); ok {
+			return strings.Join(l.parts[:len(l.parts)-1], " "), start, end, confident, true
+		}
+	}
+	if text, start, end, ok := trailingDate(l.text); ok && text != "" {
+		return text, start, end, guessed, true
+	}
+	return l.text, "", "", 0, false
+}
+
+// sides splits a line into its left text and right-aligned text, e.g. a
+// company and its location
+func sides(l line) (left, right string) {
+	if len(l.parts) > 1 {
+		return strings.Join(l.parts[:len(l.parts)-1], " "), l.parts[len(l.parts)-1]
+	}
+	return l.text, ""
+}
+
+func (r *sectionReader) summary(title string, confidence float64) {
+	content := models.ProfileSummaryContent{Format: "paragraph"}
+	if len(r.lines) > 0 && r.lines[0].bullet {
+		content.Format = "bullets"
+		content.Bullets, _ = r.bullets(0)
+	} else {
+		content.Text = paragraph(r.lines)
+	}
+
+	path := r.b.addSection("profile_summary", title, content, confidence)
+	r.b.score(path+".content.text", content.Text, likely)
+	for i, bullet := range content.Bullets {
+		r.b.score(fmt.Sprintf("%s.content.bullets.%d", path, i), bullet, confident)
+	}
+}
+
+// skills reads table rows ("Languages   Go, Python"), "Category: skills"
+// lines or a single comma-separated list
+func (r *sectionReader) skills(title string, confidence float64) {
+	var content models.TechSkillsContent
+	skillsX := math.Inf(1) // Where the skills cell of the last table row starts
+	for _, l := range r.lines {
+		n := len(content.Categories)
+		name, list, labeled := strings.Cut(l.text, ":")
+		switch {
+		case n > 0 && len(l.parts) == 1 && math.Abs(l.x()-skillsX) < indentTolerance:
+			content.Categories[n-1].Skills = joinList(content.Categories[n-1].Skills, l.text)
+		case len(l.parts) > 1:
+			content.Categories = append(content.Categories, models.SkillCategory{
+				Name:   l.parts[0],
+				Skills: strings.Join(l.parts[1:], ", "),
+			})
+			skillsX = l.xs[1]
+			continue
+		case labeled && !strings.Contains(name, ","):
+			content.Categories = append(content.Categories, models.SkillCategory{Name: strings.TrimSpace(name), Skills: strings.TrimSpace(list)})
+		case n > 0 && r.continues(l):
+			content.Categories[n-1].Skills = joinList(content.Categories[n-1].Skills, l.text)
+		case strings.Contains(l.text, ","):
+			content.Categories = append(content.Categories, models.SkillCategory{Name: "Skills", Skills: l.text})
+			content.Layout = models.SkillsLayoutList
+		default:
+			content.Categories = append(content.Categories, models.SkillCategory{Name: l.text})
+		}
+		skillsX = math.Inf(1)
+	}
+
+	path := r.b.addSection("tech_skills", title, content, confidence)
+	for i, c := range content.Categories {
+		r.b.score(fmt.Sprintf("%s.content.categories.%d.name", path, i), c.Name, likely)
+		r.b.score(fmt.Sprintf("%s.content.categories.%d.skills", path, i), c.Skills, confident)
+	}
+}
+
+// joinList appends a wrapped part of a comma-separated list
+func joinList(list, next string) string {
+	if strings.HasSuffix(list, ",") {
+		return list + " " + next
+	}
+	return joinWrapped(list, next)
+}
+
+// experience reads experience or volunteer entries. A line with dates
+// starts an entry, as in the service's layout:
+//
+//	Title                          Jan 2020 – Present
+//	Company                                   Toronto
+//	• Bullet
+//
+// A line without dates followed by dated lines is a company header with
+// several positions below it.
+func (r *sectionReader) experience(sectionType, title string, confidence float64) {
+	orgField := "company"
+	if sectionType == "volunteer" {
+		orgField = "organization"
+	}
+
+	var entries []map[string]interface{}
+	var scores []map[string]float64
+	var target map[string]interface{} // Entry or position receiving bullets
+	var targetScores map[string]float64
+	expectOrg := false
+	var header *line // Company line waiting for its positions
+
+	for i := 0; i < len(r.lines); {
+		l := r.lines[i]
+		if l.bullet {
+			items, next := r.bullets(i)
+			if target == nil {
+				target = map[string]interface{}{orgField: "", "title": ""}
+				targetScores = map[string]float64{}
+				entries, scores = append(entries, target), append(scores, targetScores)
+			}
+			existing, _ := target["bullets"].([]string)
+			target["bullets"] = append(existing, items...)
+			targetScores["bullets"] = confident
+			i, expectOrg = next, false
+			continue
+		}
+
+		text, start, end, dateConfidence, ok := dated(l)
+		switch {
+		case ok && header != nil:
+			// First position under a company header
+			org, location := sides(*header)
+			entry := map[string]interface{}{orgField: org, "location": location}
+			entries = append(entries, entry)
+			scores = append(scores, map[string]float64{orgField: likely, "location": likely})
+			target, targetScores = position(text, start, end, dateConfidence)
+			entry["positions"] = []map[string]interface{}{target}
+			entry["positionScores"] = []map[string]float64{targetScores}
+			header = nil
+		case ok && len(entries) > 0 && entries[len(entries)-1]["positions"] != nil && !r.orgFollows(i):
+			// Another position at the same company
+			entry := entries[len(entries)-1]
+			target, targetScores = position(text, start, end, dateConfidence)
+			entry["positions"] = append(entry["positions"].([]map[string]interface{}), target)
+			entry["positionScores"] = append(entry["positionScores"].([]map[string]float64), targetScores)
+		case ok:
+			target, targetScores = position(text, start, end, dateConfidence)
+			entries, scores = append(entries, target), append(scores, targetScores)
+			expectOrg = true
+		case expectOrg:
+			org, location := sides(l)
+			target[orgField], target["location"] = org, location
+			targetScores[orgField], targetScores["location"] = likely, likely
+			expectOrg = false
+		case target != nil && r.continues(l):
+			// A wrapped title or company line
+			if org, _ := target[orgField].(string); org != "" {
+				target[orgField] = joinWrapped(org, l.text)
+			} else {
+				target["title"] = joinWrapped(target["title"].(string), l.text)
+			}
+		default:
+			if header != nil {
+				r.b.warn("Could not read %q in %s", header.text, sectionType)
+			}
+			h := l
+			header = &h
+		}
+		i++
+	}
+	if header != nil {
+		org, location := sides(*header)
+		entries = append(entries, map[string]interface{}{orgField: org, "location": location})
+		scores = append(scores, map[string]float64{orgField: guessed, "location": guessed})
+	}
+
+	// A header with a single position is an ordinary entry
+	list := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		positions, _ := entry["positions"].([]map[string]interface{})
+		positionScores, _ := entry["positionScores"].([]map[string]float64)
+		delete(entry, "positions")
+		delete(entry, "positionScores")
+		switch {
+		case len(positions) == 1:
+			for k, v := range positions[0] {
+				entry[k] = v
+			}
+			for k, v := range positionScores[0] {
+				scores[i][k] = math.Min(v, likely)
+			}
+		case len(positions) > 1:
+			grouped := make([]interface{}, len(positions))
+			for j, p := range positions {
+				grouped[j] = p
+				for k, v := range positionScores[j] {
+					scores[i][fmt.Sprintf("positions.%d.%s", j, k)] = v
+				}
+			}
+			entry["positions"] = grouped
+		}
+		list[i] = entry
+	}
+
+	path := r.b.addSection(sectionType, title, map[string]interface{}{"entries": list}, confidence)
+	for i, entry := range entries {
+		r.scoreEntry(fmt.Sprintf("%s.content.entries.%d", path, i), entry, scores[i])
+	}
+}
+
+// orgFollows reports whether the line after a dated line is a company line
+// rather than bullets or another position
+func (r *sectionReader) orgFollows(i int) bool {
+	if i+1 >= len(r.lines) {
+		return false
+	}
+	next := r.lines[i+1]
+	if next.bullet {
+		return false
+	}
+	_, _, _, _, ok := dated(next)
+	return !ok
+}
+
+// position builds an entry or position from a dated line
+func position(title, start, end string, dateConfidence float64) (map[string]interface{}, map[string]float64) {
+	p := map[string]interface{}{"title": title, "startDate": start, "endDate": end}
+	scores := map[string]float64{"title": likely, "startDate": dateConfidence, "endDate": dateConfidence}
+	if end == "Present" {
+		p["endDate"], p["current"] = "", true
+		scores["current"] = dateConfidence
+	}
+	return p, scores
+}
+
+// scoreEntry records the confidence of an entry's fields
+func (r *sectionReader) scoreEntry(path string, entry map[string]interface{}, scores map[string]float64) {
+	for field, confidence := range scores {
+		value := entry[field]
+		if position, key, ok := strings.Cut(field, "."); ok {
+			// A field of a grouped position, e.g. "positions.0.title"
+			var j int
+			fmt.Sscanf(key, "%d.%s", &j, &key)
+			positions, _ := entry["positions"].([]interface{})
+			p, _ := positions[j].(map[string]interface{})
+			value, field = p[key], fmt.Sprintf("%s.%d.%s", position, j, key)
+		}
+		switch v := value.(type) {
+		case string:
+			r.b.score(path+"."+field, v, confidence)
+		case []string:
+			for i, item := range v {
+				r.b.score(fmt.Sprintf("%s.%s.%d", path, field, i), item, confidence)
+			}
+		case bool:
+			r.b.result.Confidence[path+"."+field] = confidence
+		}
+	}
+}
+
+// projects reads "Name | Technologies (Links)   Date" headers followed by
+// bullets. Headers in the older "Name. Description (Link)" layout carry the
+// description on the same line, continued on the lines that wrap it.
+func (r *sectionReader) projects(title string, confidence float64) {
+	var entries []models.ProjectEntry
+	var scores []float64
+	for i := 0; i < len(r.lines); {
+		l := r.lines[i]
+		if l.bullet {
+			items, next := r.bullets(i)
+			if len(entries) == 0 {
+				entries, scores = append(entries, models.ProjectEntry{}), append(scores, guessed)
+			}
+			last := &entries[len(entries)-1]
+			last.Description = append(last.Description, items...)
+			i = next
+			continue
+		}
+
+		if tech, ok := cutLabel(l.text, "technologies"); ok && len(entries) > 0 {
+			entries[len(entries)-1].Technologies = tech
+			i++
+			continue
+		}
+		if len(entries) > 0 && r.continues(l) {
+			last := &entries[len(entries)-1]
+			if n := len(last.Description); n > 0 {
+				last.Description[n-1] = stripLinkLabels(joinWrapped(last.Description[n-1], l.text))
+			} else {
+				last.Name = joinWrapped(last.Name, l.text)
+			}
+			last.Links = append(last.Links, projectLinks(l.links)...)
+			i++
+			continue
+		}
+		// Punctuation alone is what an empty entry renders as, e.g. "."
+		if !strings.ContainsFunc(l.text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			i++
+			continue
+		}
+
+		text, date, _, dateConfidence, _ := dated(l)
+		entry := models.ProjectEntry{Date: date, Links: projectLinks(l.links)}
+		name, tech, piped := strings.Cut(stripLinkLabels(text), " | ")
+		if !piped {
+			if head, description, ok := inlineDescription(name); ok {
+				name, entry.Description = head, []string{description}
+			}
+		}
+		entry.Name, entry.Technologies = strings.TrimSpace(name), strings.TrimSpace(tech)
+		entries = append(entries, entry)
+		scores = append(scores, math.Max(dateConfidence, likely))
+		i++
+	}
+
+	path := r.b.addSection("projects", title, models.ProjectsContent{Entries: entries}, confidence)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		r.b.score(p+".name", e.Name, scores[i])
+		r.b.score(p+".technologies", e.Technologies, likely)
+		r.b.score(p+".date", e.Date, scores[i])
+		for j, d := range e.Description {
+			r.b.score(fmt.Sprintf("%s.description.%d", p, j), d, confident)
+		}
+	}
+}
+
+// inlineDescription splits "Name. Description" at the end of the first
+// sentence when it is short enough to be a name
+func inlineDescription(s string) (name, description string, ok bool) {
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] != '.' || s[i+1] != ' ' {
+			continue
+		}
+		name = s[:i]
+		if words := strings.Fields(name); len(words) == 0 || len(words) > 6 || len([]rune(words[len(words)-1])) == 1 {
+			return "", "", false // Too long for a name, or ends in an initial
+		}
+		if description = strings.TrimSpace(s[i+2:]); description == "" {
+			return "", "", false
+		}
+		return name, description, true
+	}
+	return "", "", false
+}
+
+// projectLinks files links under the kind their label names, e.g. "(Repo)"
+func projectLinks(found []link) []models.ProjectLink {
+	var result []models.ProjectLink
+	for _, l := range found {
+		label := strings.Trim(l.label, "() ")
+		switch kind := strings.ToLower(label); kind {
+		case "repo", "demo", "paper", "video", "docs":
+			result = append(result, models.ProjectLink{Kind: kind, URL: l.url})
+		case "link", "":
+			result = append(result, models.ProjectLink{Kind: "other", URL: l.url})
+		default:
+			result = append(result, models.ProjectLink{Kind: "other", Label: label, URL: l.url})
+		}
+	}
+	return result
+}
+
+// stripLinkLabels removes the link labels the renderer prints after a
+// project name, e.g. "(Repo)" or "(Link)", whose URLs are lost in the text
+func stripLinkLabels(s string) string {
+	for {
+		trimmed := strings.TrimSpace(s)
+		open := strings.LastIndex(trimmed, " (")
+		if open < 0 || !strings.HasSuffix(trimmed, ")") || strings.Contains(trimmed[open+2:len(trimmed)-1], " ") {
+			return trimmed
+		}
+		s = trimmed[:open]
+	}
+}
+
+// education reads "Degree, Institution, Location   Dates" lines followed by
+// labeled detail lines such as "GPA: 3.8/4.0"
+func (r *sectionReader) education(title string, confidence float64) {
+	var entries []models.EducationEntry
+	var scores []float64
+	for _, l := range r.lines {
+		if n := len(entries); n > 0 {
+			e := &entries[n-1]
+			if r.educationDetail(e, l.text) {
+				continue
+			}
+		}
+
+		text, start, end, dateConfidence, _ := dated(l)
+		if len(entries) > 0 && r.continues(l) {
+			last := &entries[len(entries)-1]
+			last.Institution = joinWrapped(last.Institution, text)
+			continue
+		}
+
+		parts := strings.SplitN(text, ", ", 3)
+		e := models.EducationEntry{Degree: strings.TrimSpace(parts[0]), StartDate: start, EndDate: end}
+		if len(parts) > 1 {
+			e.Institution = strings.TrimSpace(parts[1])
+		}
+		if len(parts) > 2 {
+			e.Location = strings.TrimSpace(parts[2])
+		}
+		switch {
+		case e.EndDate == "Present":
+			e.EndDate, e.Current = "", true
+		case e.EndDate == "":
+			// A single date is the graduation date
+			e.StartDate, e.EndDate = "", e.StartDate
+		}
+		entries = append(entries, e)
+		scores = append(scores, math.Max(dateConfidence, guessed))
+	}
+
+	path := r.b.addSection("education", title, models.EducationContent{Entries: entries}, confidence)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		r.b.score(p+".degree", e.Degree, likely)
+		r.b.score(p+".institution", e.Institution, likely)
+		r.b.score(p+".location", e.Location, guessed)
+		r.b.score(p+".startDate", e.StartDate, scores[i])
+		r.b.score(p+".endDate", e.EndDate, scores[i])
+		r.b.score(p+".gpa", string(e.GPA), confident)
+		r.b.score(p+".thesis", e.Thesis, confident)
+		for field, list := range map[string][]string{"honors": e.Honors, "minors": e.Minors, "coursework": e.Coursework} {
+			for j, item := range list {
+				r.b.score(fmt.Sprintf("%s.%s.%d", p, field, j), item, confident)
+			}
+		}
+	}
+}
+
+// educationDetail reads a labeled detail line into the entry
+func (r *sectionReader) educationDetail(e *models.EducationEntry, text string) bool {
+	if v, ok := cutLabel(text, "gpa"); ok {
+		gpa, scale, _ := strings.Cut(v, "/")
+		e.GPA, e.GPAScale = models.NumericString(gpa), models.NumericString(scale)
+		return true
+	}
+	if v, ok := cutLabel(text, "honors"); ok {
+		e.Honors = splitList(v)
+		return true
+	}
+	if v, ok := cutLabel(text, "minors"); ok {
+		e.Minors = splitList(v)
+		return true
+	}
+	if v, ok := cutLabel(text, "thesis"); ok {
+		e.Thesis = v
+		return true
+	}
+	if v, ok := cutLabel(text, "coursework"); ok {
+		e.Coursework = splitList(v)
+		return true
+	}
+	return false
+}
+
+// certifications reads "Name, Issuer (Credential ID)   Dates" lines
+func (r *sectionReader) certifications(title string, confidence float64) {
+	var entries []models.CertificationEntry
+	var scores []float64
+	for _, l := range r.lines {
+		if len(entries) > 0 && r.continues(l) {
+			last := &entries[len(entries)-1]
+			last.Name = joinWrapped(last.Name, l.text)
+			continue
+		}
+		text, start, end, dateConfidence, _ := dated(l)
+		e := models.CertificationEntry{Date: start, ExpiryDate: end}
+		if open := strings.LastIndex(text, " ("); open >= 0 && strings.HasSuffix(text, ")") {
+			if id := text[open+2 : len(text)-1]; id != "Link" {
+				e.CredentialID = id
+			}
+			text = text[:open]
+		}
+		name, issuer, _ := strings.Cut(text, ", ")
+		e.Name, e.Issuer = strings.TrimSpace(name), strings.TrimSpace(issuer)
+		entries = append(entries, e)
+		scores = append(scores, dateConfidence)
+	}
+
+	path := r.b.addSection("certifications", title, models.CertificationsContent{Entries: entries}, confidence)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		r.b.score(p+".name", e.Name, likely)
+		r.b.score(p+".issuer", e.Issuer, guessed)
+		r.b.score(p+".credentialId", e.CredentialID, guessed)
+		r.b.score(p+".date", e.Date, scores[i])
+		r.b.score(p+".expiryDate", e.ExpiryDate, scores[i])
+	}
+}
+
+// awards reads "Title, Issuer   Date" lines, each optionally followed by a
+// description line
+func (r *sectionReader) awards(title string, confidence float64) {
+	var entries []models.AwardEntry
+	var scores []float64
+	for _, l := range r.lines {
+		text, start, _, dateConfidence, ok := dated(l)
+		if !ok && len(entries) > 0 {
+			last := &entries[len(entries)-1]
+			last.Description = joinWrapped(last.Description, l.text)
+			continue
+		}
+		name, issuer, _ := strings.Cut(text, ", ")
+		entries = append(entries, models.AwardEntry{Title: strings.TrimSpace(name), Issuer: strings.TrimSpace(issuer), Date: start})
+		scores = append(scores, dateConfidence)
+	}
+
+	path := r.b.addSection("awards", title, models.AwardsContent{Entries: entries}, confidence)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		r.b.score(p+".title", e.Title, likely)
+		r.b.score(p+".issuer", e.Issuer, guessed)
+		r.b.score(p+".date", e.Date, scores[i])
+		r.b.score(p+".description", e.Description, guessed)
+	}
+}
+
+// publications reads citations of the form "Authors. Title. Venue, Year.
+// doi:..." with each citation starting at the left edge
+func (r *sectionReader) publications(title string, confidence float64) {
+	var citations []string
+	for _, l := range r.lines {
+		if len(citations) > 0 && r.continues(l) {
+			citations[len(citations)-1] = joinWrapped(citations[len(citations)-1], l.text)
+		} else {
+			citations = append(citations, l.text)
+		}
+	}
+
+	entries := make([]models.PublicationEntry, len(citations))
+	for i, c := range citations {
+		entries[i] = parseCitation(c)
+	}
+
+	path := r.b.addSection("publications", title, models.PublicationsContent{Entries: entries}, confidence)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		r.b.score(p+".title", e.Title, guessed)
+		r.b.score(p+".venue", e.Venue, guessed)
+		r.b.score(p+".year", e.Year, likely)
+		r.b.score(p+".doi", e.DOI, confident)
+		for j, a := range e.Authors {
+			r.b.score(fmt.Sprintf("%s.authors.%d", p, j), a, guessed)
+		}
+	}
+}
+
+// parseCitation splits "Authors. Title. Venue, Year. doi:10.1/x"
+func parseCitation(c string) models.PublicationEntry {
+	var e models.PublicationEntry
+	if i := strings.Index(c, "doi:"); i >= 0 {
+		e.DOI = strings.TrimSpace(c[i+len("doi:"):])
+		c = strings.TrimSpace(c[:i])
+	}
+	c = strings.TrimSpace(strings.TrimSuffix(stripLinkLabels(c), "."))
+
+	parts := sentences(c)
+	switch len(parts) {
+	case 1:
+		e.Title = parts[0]
+	case 2:
+		e.Title, e.Venue = parts[0], parts[1]
+	default:
+		e.Authors = splitList(parts[0])
+		e.Title = parts[1]
+		e.Venue = strings.Join(parts[2:], ". ")
+	}
+	if venue, year, ok := strings.Cut(e.Venue, ", "); ok && yearOnly(year) {
+		e.Venue, e.Year = venue, year
+	} else if yearOnly(e.Venue) {
+		e.Venue, e.Year = "", e.Venue
+	}
+	return e
+}
+
+// sentences splits a citation at ". ", but not after initials such as the
+// "J." in "J. Smith"
+func sentences(s string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] != '.' || s[i+1] != ' ' {
+			continue
+		}
+		words := strings.Fields(s[start:i])
+		if len(words) > 0 && len([]rune(words[len(words)-1])) == 1 {
+			continue
+		}
+		parts = append(parts, strings.TrimSpace(s[start:i]))
+		start = i + 2
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+func yearOnly(s string) bool {
+	if len(s) != 4 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// languages reads "English (Native), French (Fluent)"
+func (r *sectionReader) languages(title string, confidence float64) {
+	var entries []models.LanguageEntry
+	for _, item := range splitList(paragraph(r.lines)) {
+		e := models.LanguageEntry{Name: item}
+		if open := strings.Index(item, " ("); open >= 0 && strings.HasSuffix(item, ")") {
+			e.Name = item[:open]
+			label := item[open+2 : len(item)-1]
+			if level, ok := proficiencyLevel(label); ok {
+				e.Proficiency = level
+			} else {
+				e.Name = item
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	path := r.b.addSection("languages", title, models.LanguagesContent{Entries: entries}, confidence)
+	for i, e := range entries {
+		p := fmt.Sprintf("%s.content.entries.%d", path, i)
+		r.b.score(p+".name", e.Name, likely)
+		r.b.score(p+".proficiency", e.Proficiency, likely)
+	}
+}
+
+func (r *sectionReader) interests(title string, confidence float64) {
+	items := splitList(paragraph(r.lines))
+	path := r.b.addSection("interests", title, models.InterestsContent{Items: items}, confidence)
+	for i, item := range items {
+		r.b.score(fmt.Sprintf("%s.content.items.%d", path, i), item, likely)
+	}
+}
+
+// custom keeps an unrecognized section as bullets, a two-column table or a
+// paragraph
+func (r *sectionReader) custom(title string, confidence float64) {
+	content := models.CustomContent{Layout: models.CustomLayoutParagraph}
+	bullets, next := r.bullets(0)
+	tabular := len(r.lines) > 0
+	for _, l := range r.lines {
+		tabular = tabular && len(l.parts) == 2
+	}
+	switch {
+	case len(bullets) > 0 && next == len(r.lines):
+		content.Layout, content.Bullets = models.CustomLayoutBullets, bullets
+	case tabular:
+		content.Layout = models.CustomLayoutTable
+		for _, l := range r.lines {
+			content.Rows = append(content.Rows, models.CustomRow{Key: l.parts[0], Value: l.parts[1]})
+		}
+	default:
+		content.Text = paragraph(r.lines)
+	}
+
+	path := r.b.addSection("custom", title, content, confidence)
+	r.b.score(path+".title", title, confidence)
+	r.b.score(path+".content.text", content.Text, guessed)
+	for i, bullet := range content.Bullets {
+		r.b.score(fmt.Sprintf("%s.content.bullets.%d", path, i), bullet, likely)
+	}
+	for i, row := range content.Rows {
+		r.b.score(fmt.Sprintf("%s.content.rows.%d.key", path, i), row.Key, guessed)
+		r.b.score(fmt.Sprintf("%s.content.rows.%d.value", path, i), row.Value, guessed)
+	}
+}
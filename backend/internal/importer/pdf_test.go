@@ -0,0 +1,161 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/latex"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+	"github.com/sahil/ats-resume-maker/backend/internal/pdftext"
+)
+
+const sampleResume = `{"basicDetails":{"firstName":"Jane","lastName":"Doe","email":"jane@example.com","phone":"+1 416 555 0100","city":"Toronto","province":"ON"},
+"sections":[
+	{"type":"experience","content":{"entries":[
+		{"company":"Acme","title":"Senior Engineer","location":"Toronto, ON","startDate":"Jan 2021","current":true,
+		 "bullets":["Led the migration of billing to Go","Cut p99 latency by 40%"]}]}},
+	{"type":"education","content":{"entries":[
+		{"institution":"State University","degree":"BSc Computer Science","startDate":"2013","endDate":"2017"}]}},
+	{"type":"tech_skills","content":{"categories":[{"name":"Languages","skills":"Go, Python, SQL"}]}}]}`
+
+// TestFromPDFRoundTrip imports a PDF this service generated, the baseline the
+// PDF importer is held to
+func TestFromPDFRoundTrip(t *testing.T) {
+	if _, err := exec.LookPath("pdflatex"); err != nil {
+		t.Skip("pdflatex is not installed")
+	}
+	var req models.ResumeRequest
+	if err := json.Unmarshal([]byte(sampleResume), &req); err != nil {
+		t.Fatalf("invalid test resume: %v", err)
+	}
+	compiled, err := latex.NewCompiler("../../templates", t.TempDir()).CompileResumeContext(context.Background(), &req, nil)
+	if err != nil {
+		t.Fatalf("compile error = %v", err)
+	}
+
+	result, err := FromPDF(compiled.PDF)
+	if err != nil {
+		t.Fatalf("FromPDF error = %v", err)
+	}
+
+	bd := result.Resume.BasicDetails
+	if bd.FirstName != "Jane" || bd.LastName != "Doe" || bd.Email != "jane@example.com" {
+		t.Errorf("basic details = %+v", bd)
+	}
+
+	var experience models.ExperienceContent
+	section(t, result, "experience", &experience)
+	if len(experience.Entries) != 1 {
+		t.Fatalf("experience entries = %+v", experience.Entries)
+	}
+	job := experience.Entries[0]
+	if job.Company != "Acme" || job.Title != "Senior Engineer" || job.StartDate != "Jan 2021" || !job.Current {
+		t.Errorf("experience entry = %+v", job)
+	}
+	if len(job.Bullets) != 2 || job.Bullets[1] != "Cut p99 latency by 40%" {
+		t.Errorf("bullets = %q", job.Bullets)
+	}
+
+	var education models.EducationContent
+	section(t, result, "education", &education)
+	if len(education.Entries) != 1 || education.Entries[0].Institution != "State University" {
+		t.Errorf("education entries = %+v", education.Entries)
+	}
+
+	var skills models.TechSkillsContent
+	section(t, result, "tech_skills", &skills)
+	if len(skills.Categories) != 1 || skills.Categories[0].Skills != "Go, Python, SQL" {
+		t.Errorf("skills = %+v", skills.Categories)
+	}
+}
+
+// TestLocalizedHeadingsExtract checks that accented headings extract as whole
+// characters rather than a letter followed by a separate accent
+func TestLocalizedHeadingsExtract(t *testing.T) {
+	if _, err := exec.LookPath("pdflatex"); err != nil {
+		t.Skip("pdflatex is not installed")
+	}
+	var req models.ResumeRequest
+	if err := json.Unmarshal([]byte(sampleResume), &req); err != nil {
+		t.Fatalf("invalid test resume: %v", err)
+	}
+	req.Locale = "fr"
+	compiled, err := latex.NewCompiler("../../templates", t.TempDir()).CompileResumeContext(context.Background(), &req, nil)
+	if err != nil {
+		t.Fatalf("compile error = %v", err)
+	}
+
+	doc, err := pdftext.Extract(compiled.PDF)
+	if err != nil {
+		t.Fatalf("Extract error = %v", err)
+	}
+	if text := doc.Text(); !strings.Contains(text, "EXPÉRIENCE PROFESSIONNELLE") {
+		t.Errorf("extracted text lacks the French experience heading:\n%s", text)
+	}
+}
+
+// TestFromPDFFixtures imports PDFs produced by earlier versions of the
+// template, so the importer is exercised without a TeX installation
+func TestFromPDFFixtures(t *testing.T) {
+	sahil := fixture(t, "sahil_gogna.pdf")
+	bd := sahil.Resume.BasicDetails
+	if bd.FirstName != "Sahil" || bd.LastName != "Gogna" || bd.City != "Kitchener" || bd.GitHub != "https://github.com/SahilGogna" {
+		t.Errorf("basic details = %+v", bd)
+	}
+	var experience models.ExperienceContent
+	section(t, sahil, "experience", &experience)
+	if len(experience.Entries) != 2 || experience.Entries[1].Company != "AWS" || !experience.Entries[1].Current {
+		t.Errorf("experience entries = %+v", experience.Entries)
+	}
+	var projects models.ProjectsContent
+	section(t, sahil, "projects", &projects)
+	want := []models.ProjectEntry{{
+		Name:        "Calculator app",
+		Description: []string{"Cool description of the project.. this is point number 2"},
+		Links:       []models.ProjectLink{{Kind: "other", URL: "https://github.com/SahilGogna/cricket-club-portfolio"}},
+	}}
+	if !reflect.DeepEqual(projects.Entries, want) {
+		t.Errorf("projects = %+v, want %+v", projects.Entries, want)
+	}
+
+	// An empty project renders as a lone "."
+	john := fixture(t, "john_doe.pdf")
+	section(t, john, "projects", &projects)
+	if len(projects.Entries) != 0 {
+		t.Errorf("projects = %+v, want none", projects.Entries)
+	}
+	var education models.EducationContent
+	section(t, john, "education", &education)
+	if len(education.Entries) != 1 || education.Entries[0].Institution != "University of Toronto" || education.Entries[0].EndDate != "2020" {
+		t.Errorf("education entries = %+v", education.Entries)
+	}
+
+	header := fixture(t, "header_only.pdf")
+	if header.Resume.BasicDetails.Email != "gogna.sahil95@gmail.com" || len(header.Resume.Sections) != 0 || len(header.Warnings) != 1 {
+		t.Errorf("header-only import = %+v, warnings %q", header.Resume, header.Warnings)
+	}
+}
+
+func fixture(t *testing.T, name string) *models.ImportResult {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := FromPDF(data)
+	if err != nil {
+		t.Fatalf("FromPDF(%s) error = %v", name, err)
+	}
+	return result
+}
+
+func TestFromPDFRejects(t *testing.T) {
+	if _, err := FromPDF([]byte("not a PDF")); err == nil {
+		t.Error("FromPDF accepted a non-PDF upload")
+	}
+}
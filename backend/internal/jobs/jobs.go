@@ -0,0 +1,319 @@
+// Package jobs runs compilations in the background and keeps their results
+// in memory until they expire.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+var (
+	// ErrNotFound is returned when no job has the requested ID, including
+	// jobs that have expired
+	ErrNotFound = errors.New("job not found")
+	// ErrFinished is returned when canceling a job that already finished
+	ErrFinished = errors.New("job already finished")
+	// ErrFull is returned when as many jobs as allowed are queued or running,
+	// or unexpired results already fill the limits on finished jobs
+	ErrFull = errors.New("too many jobs")
+)
+
+// Func runs a job, reporting its progress to p. It should return promptly
+// once ctx is canceled.
+type Func func(ctx context.Context, p *Progress) (*Output, error)
+
+// Output is what a successful job produced
+type Output struct {
+	File   []byte // Served for download until the job expires
+	Result *models.JobResult
+}
+
+// Manager queues jobs, runs a bounded number at once and discards finished
+// jobs once their TTL has passed. Results are never discarded early: new
+// jobs are refused while limit finished jobs are held or their files fill
+// the file budget.
+type Manager struct {
+	ttl        time.Duration
+	limit      int
+	fileBudget int
+	slots      chan struct{}
+
+	mu   sync.Mutex
+	jobs map[string]*entry
+}
+
+type entry struct {
+	job     models.Job
+	file    []byte
+	cancel  context.CancelFunc
+	changed chan struct{} // Closed and replaced on every update
+}
+
+// NewManager creates a manager running at most workers jobs at once, with
+// at most limit jobs queued or running. Submissions are refused while limit
+// finished jobs, or finished jobs holding fileBudget bytes of files, wait to
+// expire. Expired jobs are swept in the background for the life of the
+// manager.
+func NewManager(workers, limit, fileBudget int, ttl time.Duration) *Manager {
+	m := &Manager{
+		ttl:        ttl,
+		limit:      limit,
+		fileBudget: fileBudget,
+		slots:      make(chan struct{}, workers),
+		jobs:       make(map[string]*entry),
+	}
+	go m.sweepEvery(min(ttl, time.Minute))
+	return m
+}
+
+// Finished reports whether a job has stopped, successfully or not
+func Finished(job *models.Job) bool {
+	switch job.Status {
+	case models.JobStatusDone, models.JobStatusFailed, models.JobStatusCanceled:
+		return true
+	}
+	return false
+}
+
+// Submit queues a job of the given type and returns it without waiting for
+// it to run
+func (m *Manager) Submit(jobType string, run Func) (*models.Job, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active, finished, size := 0, 0, 0
+	for id, e := range m.jobs {
+		switch {
+		case expired(&e.job):
+			delete(m.jobs, id)
+		case Finished(&e.job):
+			finished++
+			size += len(e.file)
+		default:
+			active++
+		}
+	}
+	if active >= m.limit || finished >= m.limit || size >= m.fileBudget {
+		cancel()
+		return nil, ErrFull
+	}
+
+	now := time.Now()
+	e := &entry{
+		job: models.Job{
+			ID:        id,
+			Type:      jobType,
+			Status:    models.JobStatusQueued,
+			Stage:     models.JobStatusQueued,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		cancel:  cancel,
+		changed: make(chan struct{}),
+	}
+	m.jobs[id] = e
+
+	go m.run(ctx, id, run)
+
+	job := e.job
+	return &job, nil
+}
+
+// Get returns the current state of a job
+func (m *Manager) Get(id string) (*models.Job, error) {
+	job, _, err := m.Watch(id)
+	return job, err
+}
+
+// Watch returns the current state of a job and a channel that is closed on
+// its next update
+func (m *Manager) Watch(id string) (*models.Job, <-chan struct{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.lookup(id)
+	if !ok {
+		return nil, nil, ErrNotFound
+	}
+	job := e.job
+	return &job, e.changed, nil
+}
+
+// File returns a job with the file it produced, which is nil unless the job
+// is done
+func (m *Manager) File(id string) (*models.Job, []byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.lookup(id)
+	if !ok {
+		return nil, nil, ErrNotFound
+	}
+	job := e.job
+	return &job, e.file, nil
+}
+
+// Cancel stops a queued or running job. The canceled job is kept until it
+// expires.
+func (m *Manager) Cancel(id string) (*models.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.lookup(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if Finished(&e.job) {
+		return nil, ErrFinished
+	}
+
+	e.cancel()
+	m.finish(e, func(job *models.Job) {
+		job.Status = models.JobStatusCanceled
+		job.Error = "Job was canceled"
+	})
+	job := e.job
+	return &job, nil
+}
+
+// run waits for a free slot and runs the job
+func (m *Manager) run(ctx context.Context, id string, run Func) {
+	select {
+	case m.slots <- struct{}{}:
+	case <-ctx.Done():
+		return // Canceled while queued
+	}
+	defer func() { <-m.slots }()
+
+	p := &Progress{m: m, id: id}
+	p.update(func(job *models.Job) {
+		job.Status = models.JobStatusRunning
+	})
+	out, err := run(ctx, p)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.jobs[id]
+	if !ok || Finished(&e.job) {
+		return // Canceled while running
+	}
+	e.cancel()
+	m.finish(e, func(job *models.Job) {
+		if err != nil {
+			job.Status = models.JobStatusFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = models.JobStatusDone
+		job.Result = out.Result
+		e.file = out.File
+	})
+}
+
+// finish applies the final update to a job and starts its TTL. The caller
+// holds m.mu.
+func (m *Manager) finish(e *entry, apply func(*models.Job)) {
+	m.update(e, func(job *models.Job) {
+		apply(job)
+		job.Stage = job.Status
+		expires := job.UpdatedAt.Add(m.ttl)
+		job.ExpiresAt = &expires
+	})
+}
+
+// update changes a job and wakes its watchers. The caller holds m.mu.
+func (m *Manager) update(e *entry, apply func(*models.Job)) {
+	e.job.UpdatedAt = time.Now()
+	apply(&e.job)
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
+
+// lookup finds a job that has not expired. The caller holds m.mu.
+func (m *Manager) lookup(id string) (*entry, bool) {
+	e, ok := m.jobs[id]
+	if ok && expired(&e.job) {
+		delete(m.jobs, id)
+		return nil, false
+	}
+	return e, ok
+}
+
+// sweepEvery discards expired jobs at every interval, so their files are
+// released without waiting for a lookup
+func (m *Manager) sweepEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		m.mu.Lock()
+		for id, e := range m.jobs {
+			if expired(&e.job) {
+				delete(m.jobs, id)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+func expired(job *models.Job) bool {
+	return job.ExpiresAt != nil && time.Now().After(*job.ExpiresAt)
+}
+
+// Progress reports the progress of a running job
+type Progress struct {
+	m  *Manager
+	id string
+}
+
+// Stage records the stage the job has reached, e.g. "pdflatex pass 1"
+func (p *Progress) Stage(stage string) {
+	p.update(func(job *models.Job) {
+		job.Stage = stage
+	})
+}
+
+// Items records how many items a job works through
+func (p *Progress) Items(total int) {
+	p.update(func(job *models.Job) {
+		job.Total = total
+	})
+}
+
+// Complete records that one more item has finished
+func (p *Progress) Complete() {
+	p.update(func(job *models.Job) {
+		job.Completed++
+	})
+}
+
+// update changes the job unless it has finished, which it has when it was
+// canceled while running
+func (p *Progress) update(apply func(*models.Job)) {
+	p.m.mu.Lock()
+	defer p.m.mu.Unlock()
+
+	if e, ok := p.m.jobs[p.id]; ok && !Finished(&e.job) {
+		p.m.update(e, apply)
+	}
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
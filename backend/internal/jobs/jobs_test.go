@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// wait returns once a job has finished
+func wait(t *testing.T, m *Manager, id string) {
+	t.Helper()
+	for {
+		job, changed, err := m.Watch(id)
+		if err != nil {
+			t.Fatalf("Watch(%s) error = %v", id, err)
+		}
+		if Finished(job) {
+			return
+		}
+		select {
+		case <-changed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("job %s did not finish", id)
+		}
+	}
+}
+
+func produce(size int) Func {
+	return func(ctx context.Context, p *Progress) (*Output, error) {
+		return &Output{File: make([]byte, size)}, nil
+	}
+}
+
+func TestSubmitKeepsUnexpiredResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      int
+		fileBudget int
+		fileSize   int
+		accepted   int // Jobs that finish before submissions are refused
+	}{
+		{name: "finished job limit", limit: 2, fileBudget: 1 << 20, fileSize: 10, accepted: 2},
+		{name: "file budget", limit: 10, fileBudget: 100, fileSize: 60, accepted: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(1, tt.limit, tt.fileBudget, time.Hour)
+			var ids []string
+			for range tt.accepted {
+				job, err := m.Submit("resume", produce(tt.fileSize))
+				if err != nil {
+					t.Fatalf("Submit error = %v", err)
+				}
+				wait(t, m, job.ID)
+				ids = append(ids, job.ID)
+			}
+
+			if _, err := m.Submit("resume", produce(tt.fileSize)); !errors.Is(err, ErrFull) {
+				t.Errorf("Submit error = %v, want ErrFull", err)
+			}
+			for _, id := range ids {
+				if _, file, err := m.File(id); err != nil || len(file) != tt.fileSize {
+					t.Errorf("File(%s) = %d bytes, %v; want the result kept until it expires", id, len(file), err)
+				}
+			}
+		})
+	}
+}
+
+func TestSubmitAfterExpiry(t *testing.T) {
+	m := NewManager(1, 1, 1<<20, 10*time.Millisecond)
+	job, err := m.Submit("resume", produce(10))
+	if err != nil {
+		t.Fatalf("Submit error = %v", err)
+	}
+	wait(t, m, job.ID)
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := m.Submit("resume", produce(10)); err != nil {
+		t.Errorf("Submit after the result expired error = %v", err)
+	}
+	if _, err := m.Get(job.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(expired) error = %v, want ErrNotFound", err)
+	}
+}
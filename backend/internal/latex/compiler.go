@@ -2,20 +2,54 @@ package latex
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
+	"github.com/sahil/ats-resume-maker/backend/internal/dates"
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+	"github.com/sahil/ats-resume-maker/backend/internal/links"
 	"github.com/sahil/ats-resume-maker/backend/internal/models"
+	"github.com/sahil/ats-resume-maker/backend/internal/pdftext"
+	"github.com/sahil/ats-resume-maker/backend/internal/phone"
+	"github.com/sahil/ats-resume-maker/backend/internal/skills"
 )
 
 // Compiler handles LaTeX compilation to PDF
 type Compiler struct {
 	TemplateDir string
 	OutputDir   string
+
+	slots chan struct{} // Bounds the LaTeX processes running at once; nil is unbounded
+}
+
+// CompileResult holds the output of a successful compilation
+type CompileResult struct {
+	PDFName      string
+	PDF          []byte // The compiled document
+	Metrics      *models.LayoutMetrics
+	Parseability *models.ParseabilityReport
+	Tagged       bool // The kernel accepted the tagging declaration
+}
+
+// Progress receives the stages of a compilation as they start: StageGenerating,
+// then one "<engine> pass <n>" per LaTeX run, e.g. "pdflatex pass 1"
+type Progress func(stage string)
+
+// StageGenerating is reported while the LaTeX source is generated
+const StageGenerating = "generating"
+
+func (p Progress) report(stage string) {
+	if p != nil {
+		p(stage)
+	}
 }
 
 // NewCompiler creates a new LaTeX compiler
@@ -26,39 +60,95 @@ func NewCompiler(templateDir, outputDir string) *Compiler {
 	}
 }
 
-// CompileResume generates a PDF from resume data
-func (c *Compiler) CompileResume(req *models.ResumeRequest) (string, error) {
+// SetMaxConcurrent limits the LaTeX processes the compiler runs at once, over
+// every caller sharing it. Further compilations wait for a free slot.
+func (c *Compiler) SetMaxConcurrent(n int) {
+	c.slots = make(chan struct{}, n)
+}
+
+// CompileResume generates a PDF from resume data and writes it to the output
+// directory, where /api/download serves it
+func (c *Compiler) CompileResume(req *models.ResumeRequest) (*CompileResult, error) {
+	result, err := c.CompileResumeContext(context.Background(), req, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result, c.writeOutput(result)
+}
+
+// CompileResumeContext is CompileResume reporting its stages to progress,
+// which may be nil, without writing to the output directory. Canceling ctx
+// stops LaTeX.
+func (c *Compiler) CompileResumeContext(ctx context.Context, req *models.ResumeRequest, progress Progress) (*CompileResult, error) {
+	// Generate LaTeX content from template
+	progress.report(StageGenerating)
+	latexContent, err := c.generateLatex(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate LaTeX: %w", err)
+	}
+
+	pdfName := fmt.Sprintf("%s_%s_Resume.pdf",
+		sanitizeFilename(req.BasicDetails.FirstName),
+		sanitizeFilename(req.BasicDetails.LastName))
+
+	pdfContent, logContent, err := c.compile(ctx, latexContent, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract layout metrics from the log; a missing log only loses the metrics
+	var metrics *models.LayoutMetrics
+	if logContent != "" {
+		metrics = parseLayoutMetrics(logContent, req.Sections)
+	}
+
+	return &CompileResult{
+		PDFName:      pdfName,
+		PDF:          pdfContent,
+		Metrics:      metrics,
+		Parseability: pdftext.Check(pdfContent, req),
+		Tagged:       strings.Contains(logContent, "\n"+taggedMarker),
+	}, nil
+}
+
+// compile runs LaTeX on a document that uses resume.cls. It returns the PDF
+// and the LaTeX log, which is empty when the log could not be read.
+func (c *Compiler) compile(ctx context.Context, latexContent string, progress Progress) ([]byte, string, error) {
+	if c.slots != nil {
+		select {
+		case c.slots <- struct{}{}:
+			defer func() { <-c.slots }()
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		}
+	}
+
 	// Create unique temp directory for this compilation
 	tempDir, err := os.MkdirTemp("", "resume-*")
 	if err != nil {
-		return "", fmt.Errorf("failed to create temp directory: %w", err)
+		return nil, "", fmt.Errorf("failed to create temp directory: %w", err)
 	}
+	defer os.RemoveAll(tempDir)
 
 	// Copy the .cls file to temp directory
 	clsContent, err := os.ReadFile(filepath.Join(c.TemplateDir, "resume.cls"))
 	if err != nil {
-		return "", fmt.Errorf("failed to read resume.cls: %w", err)
+		return nil, "", fmt.Errorf("failed to read resume.cls: %w", err)
 	}
 	if err := os.WriteFile(filepath.Join(tempDir, "resume.cls"), clsContent, 0644); err != nil {
-		return "", fmt.Errorf("failed to write resume.cls: %w", err)
-	}
-
-	// Generate LaTeX content from template
-	latexContent, err := c.generateLatex(req)
-	if err != nil {
-		os.RemoveAll(tempDir)
-		return "", fmt.Errorf("failed to generate LaTeX: %w", err)
+		return nil, "", fmt.Errorf("failed to write resume.cls: %w", err)
 	}
 
 	// Write .tex file
 	texPath := filepath.Join(tempDir, "resume.tex")
 	if err := os.WriteFile(texPath, []byte(latexContent), 0644); err != nil {
-		os.RemoveAll(tempDir)
-		return "", fmt.Errorf("failed to write .tex file: %w", err)
+		return nil, "", fmt.Errorf("failed to write .tex file: %w", err)
 	}
 
-	// Run pdflatex
-	cmd := exec.Command("pdflatex",
+	// Run pdflatex, or xelatex when the content needs system fonts
+	engine := engineFor(latexContent)
+	progress.report(engine + " pass 1")
+	cmd := exec.CommandContext(ctx, engine,
 		"-interaction=nonstopmode",
 		"-output-directory="+tempDir,
 		texPath,
@@ -69,67 +159,93 @@ func (c *Compiler) CompileResume(req *models.ResumeRequest) (string, error) {
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		os.RemoveAll(tempDir)
-		return "", fmt.Errorf("pdflatex failed: %v\nstdout: %s\nstderr: %s", err, stdout.String(), stderr.String())
+		if ctx.Err() != nil {
+			return nil, "", ctx.Err()
+		}
+		return nil, "", fmt.Errorf("pdflatex failed: %v\nstdout: %s\nstderr: %s", err, stdout.String(), stderr.String())
 	}
 
-	// Move PDF to output directory
-	pdfName := fmt.Sprintf("%s_%s_Resume.pdf",
-		sanitizeFilename(req.BasicDetails.FirstName),
-		sanitizeFilename(req.BasicDetails.LastName))
-
-	srcPdf := filepath.Join(tempDir, "resume.pdf")
-	dstPdf := filepath.Join(c.OutputDir, pdfName)
-
-	// Ensure output directory exists
-	if err := os.MkdirAll(c.OutputDir, 0755); err != nil {
-		os.RemoveAll(tempDir)
-		return "", fmt.Errorf("failed to create output directory: %w", err)
+	var logContent string
+	if content, err := os.ReadFile(filepath.Join(tempDir, "resume.log")); err == nil {
+		logContent = string(content)
 	}
 
-	pdfContent, err := os.ReadFile(srcPdf)
+	pdfContent, err := os.ReadFile(filepath.Join(tempDir, "resume.pdf"))
 	if err != nil {
-		os.RemoveAll(tempDir)
-		return "", fmt.Errorf("failed to read generated PDF: %w", err)
+		return nil, "", fmt.Errorf("failed to read generated PDF: %w", err)
 	}
 
-	if err := os.WriteFile(dstPdf, pdfContent, 0644); err != nil {
-		os.RemoveAll(tempDir)
-		return "", fmt.Errorf("failed to write PDF to output: %w", err)
-	}
-
-	// Cleanup temp directory
-	os.RemoveAll(tempDir)
+	return pdfContent, logContent, nil
+}
 
-	return pdfName, nil
+// writeOutput writes a compiled PDF to the output directory under its name
+func (c *Compiler) writeOutput(result *CompileResult) error {
+	if err := os.MkdirAll(c.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(c.OutputDir, result.PDFName), result.PDF, 0644); err != nil {
+		return fmt.Errorf("failed to write PDF to output: %w", err)
+	}
+	return nil
 }
 
 func (c *Compiler) generateLatex(req *models.ResumeRequest) (string, error) {
-	tmpl := `\documentclass{resume}
+	tmpl := `{{.DocumentMetadata}}\documentclass{resume}
 
 \usepackage[left=0.4in,top=0.4in,right=0.4in,bottom=0.4in]{geometry}
+\ifdefined\pdfgentounicode\input{glyphtounicode}\pdfgentounicode=1\fi % ToUnicode maps keep ligatures such as "fi" extractable
 \newcommand{\tab}[1]{\hspace{.2667\textwidth}\rlap{#1}}
 \newcommand{\itab}[1]{\hspace{0em}\rlap{#1}}
-
-\name{ {{.Name}} }
-\address{ {{.Phone}} \\ {{.Location}} }
+{{.Preamble}}{{.FontPreamble}}{{.Metadata}}\name{ {{.Name}} }
+\address{ {{.AddressLine}} }
 \address{ {{.ContactLine}} }
 
 \begin{document}
+{{.StackedLinks}}\atsgeometry
 
 {{.Sections}}
 
+\atsmark{end:doc}
 \end{document}
 `
 
+	// Unknown options are rejected during validation; fall back to defaults
+	locale, ok := i18n.Lookup(req.Locale)
+	if !ok {
+		locale = i18n.Default()
+	}
+	dateStyle, ok := dates.ParseStyle(req.DateStyle)
+	if !ok {
+		dateStyle = dates.DefaultStyle
+	}
+	ctx := &renderContext{locale: locale, dateStyle: dateStyle, now: time.Now()}
+
 	// Build template data
 	data := map[string]string{
-		"Name":        EscapeString(req.BasicDetails.FirstName + " " + req.BasicDetails.LastName),
-		"Phone":       "", // Phone not in current model, can be added
-		"Location":    EscapeString(req.BasicDetails.City + ", " + req.BasicDetails.Province),
-		"ContactLine": c.buildContactLine(req.BasicDetails),
-		"Sections":    c.buildSections(req.Sections),
+		"Name":         EscapeString(req.BasicDetails.FirstName + " " + req.BasicDetails.LastName),
+		"AddressLine":  c.buildAddressLine(req.BasicDetails),
+		"ContactLine":  c.buildContactLine(req.BasicDetails),
+		"StackedLinks": c.buildStackedLinks(req.BasicDetails),
+		"Sections":     c.buildSections(ctx, req.Sections),
+		"FontPreamble": pdflatexPreamble,
+	}
+
+	// Devanagari text (e.g. Hindi headings) needs a dedicated font
+	for key, value := range data {
+		if hasDevanagari(value) {
+			data[key] = wrapDevanagari(value)
+			data["FontPreamble"] = devanagariPreamble
+		}
 	}
+	data["Preamble"] = metricsPreamble
+
+	// Metadata strings go into PDF strings rather than typeset text, so they
+	// are not wrapped, but Devanagari in them still needs xelatex
+	data["Metadata"] = c.buildMetadata(req, locale)
+	if hasDevanagari(data["Metadata"]) {
+		data["FontPreamble"] = devanagariPreamble
+	}
+	data["DocumentMetadata"] = buildDocumentMetadata(req.PDF, locale, data["FontPreamble"] == devanagariPreamble)
 
 	t, err := template.New("resume").Parse(tmpl)
 	if err != nil {
@@ -144,63 +260,203 @@ func (c *Compiler) generateLatex(req *models.ResumeRequest) (string, error) {
 	return buf.String(), nil
 }
 
+// buildAddressLine joins the phone and location, skipping the phone when omitted
+func (c *Compiler) buildAddressLine(bd models.BasicDetails) string {
+	var parts []string
+
+	if bd.Phone != "" {
+		if num, err := phone.Parse(bd.Phone); err == nil {
+			parts = append(parts, fmt.Sprintf("\\href{tel:%s}{%s}", num.E164(), EscapeString(num.Format())))
+		} else {
+			parts = append(parts, EscapeString(bd.Phone))
+		}
+	}
+	parts = append(parts, EscapeString(bd.City+", "+bd.Province))
+
+	return strings.Join(parts, " \\\\ ")
+}
+
 func (c *Compiler) buildContactLine(bd models.BasicDetails) string {
 	var parts []string
 
 	if bd.Email != "" {
 		parts = append(parts, fmt.Sprintf("\\href{mailto:%s}{%s}", bd.Email, EscapeString(bd.Email)))
 	}
-	if bd.LinkedIn != "" {
-		// Extract display name from URL
-		display := strings.TrimPrefix(bd.LinkedIn, "https://")
-		display = strings.TrimPrefix(display, "http://")
-		parts = append(parts, fmt.Sprintf("\\href{%s}{%s}", bd.LinkedIn, EscapeString(display)))
+	if bd.LinkLayout != "stacked" {
+		for _, link := range contactLinks(bd) {
+			parts = append(parts, FormatURL(link.URL, link.Display))
+		}
 	}
-	if bd.GitHub != "" {
-		display := strings.TrimPrefix(bd.GitHub, "https://")
-		display = strings.TrimPrefix(display, "http://")
-		parts = append(parts, fmt.Sprintf("\\href{%s}{%s}", bd.GitHub, EscapeString(display)))
+
+	return strings.Join(parts, " \\\\ ")
+}
+
+// buildStackedLinks prints each contact link on its own centered line
+// below the address block when the stacked layout is selected
+func (c *Compiler) buildStackedLinks(bd models.BasicDetails) string {
+	if bd.LinkLayout != "stacked" {
+		return ""
 	}
-	if bd.Portfolio != "" {
-		display := strings.TrimPrefix(bd.Portfolio, "https://")
-		display = strings.TrimPrefix(display, "http://")
-		parts = append(parts, fmt.Sprintf("\\href{%s}{%s}", bd.Portfolio, EscapeString(display)))
+
+	var sb strings.Builder
+	for _, link := range contactLinks(bd) {
+		sb.WriteString("\\printaddress{" + FormatURL(link.URL, link.Display) + "}\n")
 	}
+	return sb.String()
+}
 
-	return strings.Join(parts, " \\\\ ")
+// contactLinks merges the legacy GitHub/LinkedIn/Portfolio fields with the
+// generic links list and applies the requested kind order
+func contactLinks(bd models.BasicDetails) []links.Link {
+	var result []links.Link
+
+	// Legacy fields were never validated, so fall back to a plain link
+	legacy := []models.ContactLink{
+		{Kind: links.KindLinkedIn, URL: bd.LinkedIn},
+		{Kind: links.KindGitHub, URL: bd.GitHub},
+		{Kind: links.KindPersonal, URL: bd.Portfolio},
+	}
+	for _, l := range legacy {
+		if l.URL == "" {
+			continue
+		}
+		link, err := links.Normalize(l.Kind, "", l.URL)
+		if err != nil {
+			display := strings.TrimPrefix(l.URL, "https://")
+			display = strings.TrimPrefix(display, "http://")
+			link = links.Link{Kind: l.Kind, URL: l.URL, Display: display}
+		}
+		result = append(result, link)
+	}
+
+	for _, l := range bd.Links {
+		if link, err := links.Normalize(l.Kind, l.Label, l.URL); err == nil {
+			result = append(result, link)
+		}
+	}
+
+	if len(bd.LinkOrder) > 0 {
+		rank := func(kind string) int {
+			if i := slices.Index(bd.LinkOrder, kind); i >= 0 {
+				return i
+			}
+			return len(bd.LinkOrder)
+		}
+		slices.SortStableFunc(result, func(a, b links.Link) int {
+			return rank(a.Kind) - rank(b.Kind)
+		})
+	}
+
+	return result
+}
+
+// renderContext carries per-request rendering settings into the section
+// builders, plus the options of the section being built
+type renderContext struct {
+	locale     *i18n.Locale
+	dateStyle  dates.Style
+	now        time.Time
+	showTenure bool
+}
+
+// date formats and escapes a single date. Strings that fail to parse are
+// rendered as given, with month names localized.
+func (ctx *renderContext) date(s string) string {
+	d, err := dates.Parse(s)
+	if err != nil {
+		return EscapeString(ctx.locale.LocalizeDate(s))
+	}
+	return EscapeString(d.Format(ctx.dateStyle, ctx.locale))
 }
 
-func (c *Compiler) buildSections(sections []models.Section) string {
+// dateRange formats and escapes a start/end pair in the request's date style
+func (ctx *renderContext) dateRange(start, end string, current bool) string {
+	r, err := dates.ParseRange(start, end, current)
+	if err != nil {
+		var parts []string
+		for _, s := range []string{start, end} {
+			if s != "" {
+				parts = append(parts, ctx.locale.LocalizeDate(s))
+			}
+		}
+		return EscapeString(strings.Join(parts, " – "))
+	}
+
+	formatted := r.Format(ctx.dateStyle, ctx.locale)
+	if ctx.showTenure {
+		if years, months, ok := r.Tenure(ctx.now); ok {
+			formatted += " (" + dates.FormatTenure(years, months, ctx.locale) + ")"
+		}
+	}
+	return EscapeString(formatted)
+}
+
+func (c *Compiler) buildSections(ctx *renderContext, sections []models.Section) string {
 	var sb strings.Builder
 
-	for _, section := range sections {
+	for i, section := range sections {
+		// A custom title overrides the localized default heading
+		heading := section.Title
+		if heading == "" {
+			heading = ctx.locale.Heading(section.Type)
+		}
+
+		content := section.Content
+		if section.Sort == SortReverseChronological {
+			content = sortEntries(content)
+		}
+
+		sectionCtx := *ctx
+		sectionCtx.showTenure = section.ShowTenure
+
+		var body string
 		switch section.Type {
 		case "profile_summary":
-			sb.WriteString(c.buildProfileSummary(section.Content))
+			body = c.buildProfileSummary(&sectionCtx, heading, content)
 		case "tech_skills":
-			sb.WriteString(c.buildTechSkills(section.Content))
+			body = c.buildTechSkills(&sectionCtx, heading, content)
 		case "experience":
-			sb.WriteString(c.buildExperience(section.Content))
+			body = c.buildExperience(&sectionCtx, heading, content)
 		case "projects":
-			sb.WriteString(c.buildProjects(section.Content))
+			body = c.buildProjects(&sectionCtx, heading, content)
 		case "volunteer":
-			sb.WriteString(c.buildVolunteer(section.Content))
+			body = c.buildVolunteer(&sectionCtx, heading, content)
 		case "education":
-			sb.WriteString(c.buildEducation(section.Content))
+			body = c.buildEducation(&sectionCtx, heading, content)
+		case "certifications":
+			body = c.buildCertifications(&sectionCtx, heading, content)
+		case "awards":
+			body = c.buildAwards(&sectionCtx, heading, content)
+		case "publications":
+			body = c.buildPublications(&sectionCtx, heading, content)
+		case "languages":
+			body = c.buildLanguages(&sectionCtx, heading, content)
+		case "interests":
+			body = c.buildInterests(&sectionCtx, heading, content)
+		case "custom":
+			body = c.buildCustom(&sectionCtx, heading, content)
+		}
+		if body == "" {
+			continue
 		}
+
+		// Mark the section boundaries so its extent can be measured from the log
+		sb.WriteString(fmt.Sprintf("\\atsmark{start:%d}\n", i))
+		sb.WriteString(body)
+		sb.WriteString(fmt.Sprintf("\\atsmark{end:%d}\n\n", i))
 	}
 
 	return sb.String()
 }
 
-func (c *Compiler) buildProfileSummary(content interface{}) string {
+func (c *Compiler) buildProfileSummary(ctx *renderContext, heading string, content interface{}) string {
 	data, ok := content.(map[string]interface{})
 	if !ok {
 		return ""
 	}
 
 	var sb strings.Builder
-	sb.WriteString("\\begin{rSection}{OBJECTIVE}\n\n")
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
 
 	format, _ := data["format"].(string)
 	if format == "paragraph" {
@@ -222,35 +478,80 @@ func (c *Compiler) buildProfileSummary(content interface{}) string {
 	return sb.String()
 }
 
-func (c *Compiler) buildTechSkills(content interface{}) string {
-	data, ok := content.(map[string]interface{})
-	if !ok {
+func (c *Compiler) buildTechSkills(ctx *renderContext, heading string, content interface{}) string {
+	var data models.TechSkillsContent
+	if err := models.DecodeContent(content, &data); err != nil {
 		return ""
 	}
 
-	categories, _ := data["categories"].([]interface{})
-	if len(categories) == 0 {
+	groups := skills.FromCategories(data.Categories, data.NormalizeSpelling)
+	if len(groups) == 0 {
 		return ""
 	}
 
 	var sb strings.Builder
-	sb.WriteString("\\begin{rSection}{SKILLS}\n\n")
-	sb.WriteString("\\begin{tabular}{ @{} >{\\bfseries}l @{\\hspace{6ex}} l }\n")
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
 
-	for _, cat := range categories {
-		if c, ok := cat.(map[string]interface{}); ok {
-			name, _ := c["name"].(string)
-			skills, _ := c["skills"].(string)
-			sb.WriteString(EscapeString(name) + " & " + EscapeString(skills) + "\\\\\n")
+	if data.Layout == models.SkillsLayoutList {
+		var names []string
+		for _, group := range groups {
+			names = append(names, skillLabels(ctx, group.Skills)...)
+		}
+		sb.WriteString(EscapeString(strings.Join(names, ", ")) + "\n\n")
+		sb.WriteString("\\end{rSection}\n\n")
+		return sb.String()
+	}
+
+	// Rows are categories, or proficiency levels in the levels layout
+	type row struct {
+		label string
+		names []string
+	}
+	var rows []row
+	if data.Layout == models.SkillsLayoutLevels {
+		byLevel := make(map[string][]string)
+		for _, group := range groups {
+			for _, skill := range group.Skills {
+				byLevel[skill.Level] = append(byLevel[skill.Level], skill.Name)
+			}
+		}
+		for _, level := range skills.Levels {
+			if names := byLevel[level]; len(names) > 0 {
+				rows = append(rows, row{label: ctx.locale.Label(level), names: names})
+			}
+		}
+		if names := byLevel[""]; len(names) > 0 {
+			rows = append(rows, row{label: ctx.locale.Label("other"), names: names})
+		}
+	} else {
+		for _, group := range groups {
+			rows = append(rows, row{label: group.Name, names: skillLabels(ctx, group.Skills)})
 		}
 	}
 
+	sb.WriteString("\\begin{tabular}{ @{} >{\\bfseries}l @{\\hspace{6ex}} l }\n")
+	for _, r := range rows {
+		sb.WriteString(EscapeString(r.label) + " & " + EscapeString(strings.Join(r.names, ", ")) + "\\\\\n")
+	}
 	sb.WriteString("\\end{tabular}\\\\\n")
 	sb.WriteString("\\end{rSection}\n\n")
 	return sb.String()
 }
 
-func (c *Compiler) buildExperience(content interface{}) string {
+// skillLabels returns the skill names with their level in parentheses, as
+// layouts other than levels have no row that names it
+func skillLabels(ctx *renderContext, list []skills.Skill) []string {
+	labels := make([]string, len(list))
+	for i, skill := range list {
+		labels[i] = skill.Name
+		if skill.Level != "" {
+			labels[i] += " (" + ctx.locale.Label(skill.Level) + ")"
+		}
+	}
+	return labels
+}
+
+func (c *Compiler) buildExperience(ctx *renderContext, heading string, content interface{}) string {
 	data, ok := content.(map[string]interface{})
 	if !ok {
 		return ""
@@ -262,7 +563,7 @@ func (c *Compiler) buildExperience(content interface{}) string {
 	}
 
 	var sb strings.Builder
-	sb.WriteString("\\begin{rSection}{EXPERIENCE}\n\n")
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
 
 	for _, entry := range entries {
 		if e, ok := entry.(map[string]interface{}); ok {
@@ -271,23 +572,21 @@ func (c *Compiler) buildExperience(content interface{}) string {
 			location, _ := e["location"].(string)
 			startDate, _ := e["startDate"].(string)
 			endDate, _ := e["endDate"].(string)
+			current, _ := e["current"].(bool)
 			bullets, _ := e["bullets"].([]interface{})
 
-			sb.WriteString(fmt.Sprintf("\\textbf{%s} \\hfill %s - %s\\\\\n",
-				EscapeString(title), EscapeString(startDate), EscapeString(endDate)))
+			// Several roles at one employer are grouped under a company header
+			if positions, _ := e["positions"].([]interface{}); len(positions) > 0 {
+				sb.WriteString(c.buildPositions(ctx, company, location, positions))
+				sb.WriteString("\n")
+				continue
+			}
+
+			sb.WriteString(fmt.Sprintf("\\textbf{%s} \\hfill %s\\\\\n",
+				EscapeString(title), ctx.dateRange(startDate, endDate, current)))
 			sb.WriteString(fmt.Sprintf("%s \\hfill \\textit{%s}\n",
 				EscapeString(company), EscapeString(location)))
-
-			if len(bullets) > 0 {
-				sb.WriteString(" \\begin{itemize}\n")
-				sb.WriteString("    \\itemsep -3pt {}\n")
-				for _, b := range bullets {
-					if s, ok := b.(string); ok {
-						sb.WriteString("     \\item " + EscapeString(s) + "\n")
-					}
-				}
-				sb.WriteString(" \\end{itemize}\n")
-			}
+			sb.WriteString(buildBullets(bullets))
 			sb.WriteString("\n")
 		}
 	}
@@ -296,7 +595,56 @@ func (c *Compiler) buildExperience(content interface{}) string {
 	return sb.String()
 }
 
-func (c *Compiler) buildProjects(content interface{}) string {
+// buildPositions renders a company header followed by each role held there
+func (c *Compiler) buildPositions(ctx *renderContext, company, location string, positions []interface{}) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\\textbf{%s} \\hfill \\textit{%s}\\\\\n",
+		EscapeString(company), EscapeString(location)))
+
+	for i, position := range positions {
+		p, ok := position.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		title, _ := p["title"].(string)
+		startDate, _ := p["startDate"].(string)
+		endDate, _ := p["endDate"].(string)
+		current, _ := p["current"].(bool)
+		bullets, _ := p["bullets"].([]interface{})
+
+		sb.WriteString(fmt.Sprintf("\\textit{%s} \\hfill %s",
+			EscapeString(title), ctx.dateRange(startDate, endDate, current)))
+		if len(bullets) > 0 {
+			sb.WriteString("\n" + buildBullets(bullets))
+		} else if i < len(positions)-1 {
+			sb.WriteString("\\\\\n")
+		} else {
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// buildBullets renders a list of strings as a compact itemize block
+func buildBullets(bullets []interface{}) string {
+	if len(bullets) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(" \\begin{itemize}\n")
+	sb.WriteString("    \\itemsep -3pt {}\n")
+	for _, b := range bullets {
+		if s, ok := b.(string); ok {
+			sb.WriteString("     \\item " + EscapeString(s) + "\n")
+		}
+	}
+	sb.WriteString(" \\end{itemize}\n")
+	return sb.String()
+}
+
+func (c *Compiler) buildProjects(ctx *renderContext, heading string, content interface{}) string {
 	data, ok := content.(map[string]interface{})
 	if !ok {
 		return ""
@@ -308,22 +656,31 @@ func (c *Compiler) buildProjects(content interface{}) string {
 	}
 
 	var sb strings.Builder
-	sb.WriteString("\\begin{rSection}{PROJECTS}\n\n")
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
+
+	techStyle, _ := data["techStyle"].(string)
 
 	for _, entry := range entries {
 		if e, ok := entry.(map[string]interface{}); ok {
 			name, _ := e["name"].(string)
 			date, _ := e["date"].(string)
 			desc, _ := e["description"].([]interface{})
-			link, _ := e["link"].(string)
+			technologies, _ := e["technologies"].(string)
+			technologies = formatTechnologies(technologies)
 
-			// Project header line with name and date
+			// Project header line with name, tech stack suffix, links and date
 			projectHeader := fmt.Sprintf("\\textbf{%s}", EscapeString(name))
-			if link != "" {
-				projectHeader += fmt.Sprintf(" \\href{%s}{(Link)}", link)
+			if technologies != "" && techStyle != models.TechStyleLine && techStyle != models.TechStyleHidden {
+				projectHeader += " $|$ \\textit{" + EscapeString(technologies) + "}"
+			}
+			for _, link := range projectLinks(e) {
+				projectHeader += " " + FormatURL(link.URL, "("+link.Display+")")
 			}
 			if date != "" {
-				projectHeader += fmt.Sprintf(" \\hfill %s", EscapeString(date))
+				projectHeader += fmt.Sprintf(" \\hfill %s", ctx.date(date))
+			}
+			if technologies != "" && techStyle == models.TechStyleLine {
+				projectHeader += "\\\\\n\\textit{" + ctx.locale.Label("technologies") + ": " + EscapeString(technologies) + "}"
 			}
 			sb.WriteString(projectHeader + "\n")
 
@@ -347,7 +704,7 @@ func (c *Compiler) buildProjects(content interface{}) string {
 	return sb.String()
 }
 
-func (c *Compiler) buildVolunteer(content interface{}) string {
+func (c *Compiler) buildVolunteer(ctx *renderContext, heading string, content interface{}) string {
 	data, ok := content.(map[string]interface{})
 	if !ok {
 		return ""
@@ -359,7 +716,7 @@ func (c *Compiler) buildVolunteer(content interface{}) string {
 	}
 
 	var sb strings.Builder
-	sb.WriteString("\\begin{rSection}{VOLUNTEER EXPERIENCE}\n\n")
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
 
 	for _, entry := range entries {
 		if e, ok := entry.(map[string]interface{}); ok {
@@ -368,10 +725,11 @@ func (c *Compiler) buildVolunteer(content interface{}) string {
 			location, _ := e["location"].(string)
 			startDate, _ := e["startDate"].(string)
 			endDate, _ := e["endDate"].(string)
+			current, _ := e["current"].(bool)
 			bullets, _ := e["bullets"].([]interface{})
 
-			sb.WriteString(fmt.Sprintf("\\textbf{%s} \\hfill %s - %s\\\\\n",
-				EscapeString(title), EscapeString(startDate), EscapeString(endDate)))
+			sb.WriteString(fmt.Sprintf("\\textbf{%s} \\hfill %s\\\\\n",
+				EscapeString(title), ctx.dateRange(startDate, endDate, current)))
 			sb.WriteString(fmt.Sprintf("%s \\hfill \\textit{%s}\n",
 				EscapeString(org), EscapeString(location)))
 
@@ -393,7 +751,7 @@ func (c *Compiler) buildVolunteer(content interface{}) string {
 	return sb.String()
 }
 
-func (c *Compiler) buildEducation(content interface{}) string {
+func (c *Compiler) buildEducation(ctx *renderContext, heading string, content interface{}) string {
 	data, ok := content.(map[string]interface{})
 	if !ok {
 		return ""
@@ -405,7 +763,7 @@ func (c *Compiler) buildEducation(content interface{}) string {
 	}
 
 	var sb strings.Builder
-	sb.WriteString("\\begin{rSection}{Education}\n\n")
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
 
 	for _, entry := range entries {
 		if e, ok := entry.(map[string]interface{}); ok {
@@ -413,14 +771,21 @@ func (c *Compiler) buildEducation(content interface{}) string {
 			degree, _ := e["degree"].(string)
 			startDate, _ := e["startDate"].(string)
 			endDate, _ := e["endDate"].(string)
+			current, _ := e["current"].(bool)
+			location, _ := e["location"].(string)
 
-			dateStr := endDate
-			if startDate != "" && endDate != "" {
-				dateStr = startDate + " - " + endDate
+			school := EscapeString(institution)
+			if location != "" {
+				school += ", \\textit{" + EscapeString(location) + "}"
 			}
-
 			sb.WriteString(fmt.Sprintf("{\\bf %s}, %s \\hfill {%s}\\\\\n",
-				EscapeString(degree), EscapeString(institution), EscapeString(dateStr)))
+				EscapeString(degree), school, ctx.dateRange(startDate, endDate, current)))
+			// Entries with detail lines end their paragraph with a blank line,
+			// which parskip sets apart. The last line drops its \\ so the
+			// break does not leave an underfull line.
+			if details := c.buildEducationDetails(ctx, e); details != "" {
+				sb.WriteString(strings.TrimSuffix(details, "\\\\\n") + "\n\n")
+			}
 		}
 	}
 
@@ -428,6 +793,96 @@ func (c *Compiler) buildEducation(content interface{}) string {
 	return sb.String()
 }
 
+// formatTechnologies normalizes a comma-separated technology list
+func formatTechnologies(s string) string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return strings.Join(items, ", ")
+}
+
+// projectLinks collects a project's links, starting with the legacy single
+// link field. Invalid links are rejected during validation and skipped here.
+func projectLinks(e map[string]interface{}) []links.Link {
+	var result []links.Link
+
+	if link, _ := e["link"].(string); link != "" {
+		result = append(result, links.Link{Kind: "other", URL: link, Display: "Link"})
+	}
+
+	items, _ := e["links"].([]interface{})
+	for _, item := range items {
+		l, _ := item.(map[string]interface{})
+		kind, _ := l["kind"].(string)
+		label, _ := l["label"].(string)
+		url, _ := l["url"].(string)
+		if link, err := links.NormalizeProjectLink(kind, label, url); err == nil {
+			result = append(result, link)
+		}
+	}
+
+	return result
+}
+
+// buildEducationDetails renders the optional GPA, honors, minors, thesis and
+// coursework lines below an education entry
+func (c *Compiler) buildEducationDetails(ctx *renderContext, e map[string]interface{}) string {
+	var lines []string
+
+	// The GPA is shown unless explicitly hidden
+	gpa := stringValue(e["gpa"])
+	if show, ok := e["showGpa"].(bool); gpa != "" && (!ok || show) {
+		if scale := stringValue(e["gpaScale"]); scale != "" {
+			gpa += "/" + scale
+		}
+		lines = append(lines, ctx.locale.Label("gpa")+": "+EscapeString(gpa))
+	}
+	if honors := stringList(e["honors"]); len(honors) > 0 {
+		lines = append(lines, ctx.locale.Label("honors")+": "+EscapeString(strings.Join(honors, ", ")))
+	}
+	if minors := stringList(e["minors"]); len(minors) > 0 {
+		lines = append(lines, ctx.locale.Label("minors")+": "+EscapeString(strings.Join(minors, ", ")))
+	}
+	if thesis, _ := e["thesis"].(string); thesis != "" {
+		lines = append(lines, ctx.locale.Label("thesis")+": \\textit{"+EscapeString(thesis)+"}")
+	}
+	if coursework := stringList(e["coursework"]); len(coursework) > 0 {
+		lines = append(lines, ctx.locale.Label("coursework")+": "+EscapeString(strings.Join(coursework, ", "))+".")
+	}
+
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(line + "\\\\\n")
+	}
+	return sb.String()
+}
+
+// stringValue reads a JSON string or number as a string
+func stringValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return strings.TrimSpace(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return ""
+}
+
+// stringList reads a JSON array of strings, skipping blank items
+func stringList(v interface{}) []string {
+	items, _ := v.([]interface{})
+	var result []string
+	for _, item := range items {
+		if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+			result = append(result, strings.TrimSpace(s))
+		}
+	}
+	return result
+}
+
 func sanitizeFilename(s string) string {
 	// Remove any characters that could be problematic in filenames
 	result := strings.ReplaceAll(s, " ", "_")
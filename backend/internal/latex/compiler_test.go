@@ -0,0 +1,19 @@
+package latex
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCompileWaitsForSlot(t *testing.T) {
+	c := NewCompiler(t.TempDir(), t.TempDir())
+	c.SetMaxConcurrent(1)
+	c.slots <- struct{}{} // Another compilation holds the only slot
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := c.compile(ctx, "", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("compile error = %v, want context.Canceled while waiting for a slot", err)
+	}
+}
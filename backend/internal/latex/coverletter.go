@@ -0,0 +1,191 @@
+package latex
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// coverLetterTemplate uses resume.cls for the same letterhead as the resume:
+// the name and address lines are printed by the class at \begin{document}
+const coverLetterTemplate = `{{.DocumentMetadata}}\documentclass{resume}
+
+\usepackage[left=0.75in,top=0.4in,right=0.75in,bottom=0.75in]{geometry}
+\ifdefined\pdfgentounicode\input{glyphtounicode}\pdfgentounicode=1\fi % ToUnicode maps keep ligatures such as "fi" extractable
+{{.FontPreamble}}{{.Metadata}}\name{ {{.Name}} }
+\address{ {{.AddressLine}} }
+\address{ {{.ContactLine}} }
+
+\begin{document}
+{{.StackedLinks}}\sectionlineskip
+\hrule
+\bigskip
+
+{{.Date}}
+
+{{.Recipient}}{{.Salutation}}
+
+{{.Body}}
+
+{{.Closing}}
+
+\bigskip
+{{.Signature}}
+\end{document}
+`
+
+// CompileCoverLetter generates a PDF from cover letter data and writes it to
+// the output directory
+func (c *Compiler) CompileCoverLetter(req *models.CoverLetterRequest) (*CompileResult, error) {
+	result, err := c.CompileCoverLetterContext(context.Background(), req, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result, c.writeOutput(result)
+}
+
+// CompileCoverLetterContext is CompileCoverLetter reporting its stages to
+// progress, which may be nil, without writing to the output directory.
+// Canceling ctx stops LaTeX.
+func (c *Compiler) CompileCoverLetterContext(ctx context.Context, req *models.CoverLetterRequest, progress Progress) (*CompileResult, error) {
+	progress.report(StageGenerating)
+	latexContent, err := c.generateCoverLetter(req, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate LaTeX: %w", err)
+	}
+
+	pdfName := fmt.Sprintf("%s_%s_Cover_Letter.pdf",
+		sanitizeFilename(req.BasicDetails.FirstName),
+		sanitizeFilename(req.BasicDetails.LastName))
+
+	pdfContent, logContent, err := c.compile(ctx, latexContent, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompileResult{
+		PDFName: pdfName,
+		PDF:     pdfContent,
+		Tagged:  strings.Contains(logContent, "\n"+taggedMarker),
+	}, nil
+}
+
+func (c *Compiler) generateCoverLetter(req *models.CoverLetterRequest, now time.Time) (string, error) {
+	// Unknown locales are rejected during validation; fall back to the default
+	locale, ok := i18n.Lookup(req.Locale)
+	if !ok {
+		locale = i18n.Default()
+	}
+
+	name := strings.TrimSpace(req.BasicDetails.FirstName + " " + req.BasicDetails.LastName)
+	data := map[string]string{
+		"Name":         EscapeString(req.BasicDetails.FirstName + " " + req.BasicDetails.LastName),
+		"AddressLine":  c.buildAddressLine(req.BasicDetails),
+		"ContactLine":  c.buildContactLine(req.BasicDetails),
+		"StackedLinks": c.buildStackedLinks(req.BasicDetails),
+		"Date":         EscapeString(letterDate(req.Date, locale, now)),
+		"Recipient":    buildRecipient(req),
+		"Salutation":   EscapeString(salutation(req, locale)),
+		"Body":         buildParagraphs(req.Paragraphs),
+		"Closing":      EscapeString(firstNonBlank(req.Closing, locale.Label("closing"))),
+		"Signature":    EscapeString(name),
+		"FontPreamble": pdflatexPreamble,
+	}
+
+	// Devanagari text needs a dedicated font
+	for key, value := range data {
+		if hasDevanagari(value) {
+			data[key] = wrapDevanagari(value)
+			data["FontPreamble"] = devanagariPreamble
+		}
+	}
+
+	opts := req.PDF
+	if opts == nil {
+		opts = &models.PDFOptions{}
+	}
+	title := firstNonBlank(opts.Title, name+" "+locale.Label("coverLetter"))
+	subject := firstNonBlank(opts.Subject, req.Company)
+	data["Metadata"] = writeMetadata(title, name, subject, opts.Keywords, opts.PDFA, locale)
+	if hasDevanagari(data["Metadata"]) {
+		data["FontPreamble"] = devanagariPreamble
+	}
+	data["DocumentMetadata"] = buildDocumentMetadata(req.PDF, locale, data["FontPreamble"] == devanagariPreamble)
+
+	t, err := template.New("coverLetter").Parse(coverLetterTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// letterDate formats an ISO date in the locale's long form. Other strings
+// are printed as given, and an empty date means today.
+func letterDate(s string, locale *i18n.Locale, now time.Time) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return locale.FormatDay(now)
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return locale.FormatDay(t)
+	}
+	return s
+}
+
+// buildRecipient prints the recipient's name and title, the company and its
+// address as one block, followed by a paragraph break
+func buildRecipient(req *models.CoverLetterRequest) string {
+	var lines []string
+	for _, line := range append([]string{req.Recipient.Name, req.Recipient.Title, req.Company}, req.Recipient.Address...) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, EscapeString(line))
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\\\\\n") + "\n\n"
+}
+
+// salutation returns the requested salutation, or one addressed to the
+// recipient by name, or the locale's generic one
+func salutation(req *models.CoverLetterRequest, locale *i18n.Locale) string {
+	if s := strings.TrimSpace(req.Salutation); s != "" {
+		return s
+	}
+	if name := strings.TrimSpace(req.Recipient.Name); name != "" {
+		return fmt.Sprintf(locale.Label("salutationTo"), name)
+	}
+	return locale.Label("salutation")
+}
+
+// buildParagraphs separates the non-blank paragraphs with blank lines
+func buildParagraphs(paragraphs []string) string {
+	var escaped []string
+	for _, p := range paragraphs {
+		if p = strings.TrimSpace(p); p != "" {
+			escaped = append(escaped, EscapeString(p))
+		}
+	}
+	return strings.Join(escaped, "\n\n")
+}
+
+func firstNonBlank(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
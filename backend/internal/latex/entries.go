@@ -0,0 +1,99 @@
+package latex
+
+import (
+	"slices"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/dates"
+)
+
+// Section sort modes
+const (
+	SortNone                 = "none"
+	SortReverseChronological = "reverse_chronological"
+)
+
+// entryRange extracts the date range of an entry. Projects, awards,
+// certifications and publications carry a single date or year, which is
+// treated as the end of the range. Grouped experience entries
+// span from their earliest position start to their latest position end.
+func entryRange(e map[string]interface{}) (dates.Range, bool) {
+	for _, key := range []string{"date", "year"} {
+		if date, ok := e[key].(string); ok {
+			d, err := dates.Parse(date)
+			return dates.Range{End: d}, err == nil && !d.IsZero()
+		}
+	}
+
+	if positions, _ := e["positions"].([]interface{}); len(positions) > 0 {
+		var span dates.Range
+		found := false
+		for _, position := range positions {
+			p, _ := position.(map[string]interface{})
+			r, ok := entryRange(p)
+			if !ok {
+				continue
+			}
+			if !found || r.Start.Compare(span.Start) < 0 {
+				span.Start = r.Start
+			}
+			if !found || r.End.Compare(span.End) > 0 {
+				span.End = r.End
+			}
+			found = true
+		}
+		return span, found
+	}
+
+	startDate, _ := e["startDate"].(string)
+	endDate, _ := e["endDate"].(string)
+	current, _ := e["current"].(bool)
+	r, err := dates.ParseRange(startDate, endDate, current)
+	if err != nil || (r.Start.IsZero() && r.End.IsZero()) {
+		return dates.Range{}, false
+	}
+	if r.End.IsZero() {
+		r.End = r.Start
+	}
+	return r, true
+}
+
+// sortEntries returns a copy of a section's content with its entries in
+// reverse-chronological order: by end date with Present first, then by
+// start date. Entries without usable dates keep their order at the end.
+func sortEntries(content interface{}) interface{} {
+	data, ok := content.(map[string]interface{})
+	if !ok {
+		return content
+	}
+	entries, ok := data["entries"].([]interface{})
+	if !ok {
+		return content
+	}
+
+	sorted := slices.Clone(entries)
+	slices.SortStableFunc(sorted, func(a, b interface{}) int {
+		ea, _ := a.(map[string]interface{})
+		eb, _ := b.(map[string]interface{})
+		ra, okA := entryRange(ea)
+		rb, okB := entryRange(eb)
+		switch {
+		case !okA && !okB:
+			return 0
+		case !okA:
+			return 1
+		case !okB:
+			return -1
+		}
+		if c := rb.End.Compare(ra.End); c != 0 {
+			return c
+		}
+		return rb.Start.Compare(ra.Start)
+	})
+
+	result := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		result[k] = v
+	}
+	result["entries"] = sorted
+	return result
+}
@@ -44,13 +44,30 @@ func EscapeStringSlice(slice []string) []string {
 	return escaped
 }
 
+// urlReplacer escapes characters that break \href when it is used inside
+// another command's argument, such as \address. Braces and backslashes are
+// percent-encoded so a URL cannot close the argument and run commands.
+var urlReplacer = strings.NewReplacer(
+	"%", "\\%",
+	"#", "\\#",
+	"&", "\\&",
+	"{", "\\%7B",
+	"}", "\\%7D",
+	"\\", "\\%5C",
+)
+
+// EscapeURL escapes a URL for use as an \href target
+func EscapeURL(url string) string {
+	return urlReplacer.Replace(url)
+}
+
 // FormatURL creates a clickable LaTeX hyperref link
 func FormatURL(url, displayText string) string {
 	if url == "" {
 		return ""
 	}
 	escapedDisplay := EscapeString(displayText)
-	return "\\href{" + url + "}{" + escapedDisplay + "}"
+	return "\\href{" + EscapeURL(url) + "}{" + escapedDisplay + "}"
 }
 
 // FormatBulletList formats a slice of strings as LaTeX itemize bullets
@@ -0,0 +1,30 @@
+package latex
+
+import "testing"
+
+func TestEscapeURL(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/a%20b#top&x":          `https://example.com/a\%20b\#top\&x`,
+		`https://example.com/}\input{/etc/passwd}`: `https://example.com/\%7D\%5Cinput\%7B/etc/passwd\%7D`,
+		"https://example.com/?q={x}":               `https://example.com/?q=\%7Bx\%7D`,
+	}
+	for in, want := range tests {
+		if got := EscapeURL(in); got != want {
+			t.Errorf("EscapeURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWebURL(t *testing.T) {
+	tests := map[string]string{
+		"":                 "",
+		"not a url":        "",
+		"example.com/cert": "https://example.com/cert",
+		`https://example.com/}\input{/etc/passwd}`: "https://example.com/%7D%5Cinput%7B/etc/passwd%7D",
+	}
+	for in, want := range tests {
+		if got := webURL(in); got != want {
+			t.Errorf("webURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package latex
+
+import (
+	"strings"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+	"github.com/sahil/ats-resume-maker/backend/internal/skills"
+)
+
+// maxKeywords caps the keywords written into the document information
+const maxKeywords = 40
+
+// taggedMarker is written to the log when the kernel accepted the tagging
+// declaration
+const taggedMarker = "ATSTAGGED"
+
+// buildDocumentMetadata returns the declarations placed before
+// \documentclass. Tagging relies on \DocumentMetadata, which only kernels
+// from 2023-06 onwards support with the phase-III test phase; older kernels
+// skip the declaration and fall back to the untagged output. The PDF
+// management it enables writes its own XMP and PDF/A data, which
+// buildMetadata leaves alone when \atsmanagedpdf is defined. Tagging is not
+// attempted under xelatex.
+func buildDocumentMetadata(opts *models.PDFOptions, locale *i18n.Locale, xelatex bool) string {
+	var sb strings.Builder
+	sb.WriteString("\\PassOptionsToPackage{unicode}{hyperref}\n")
+	if opts == nil {
+		return sb.String()
+	}
+	if opts.PDFA {
+		// Link annotations must be printable in PDF/A
+		sb.WriteString("\\PassOptionsToPackage{pdfa}{hyperref}\n")
+	}
+
+	if opts.Tagged && !xelatex {
+		keys := "lang=" + locale.Code
+		if opts.PDFA {
+			keys += ", pdfstandard=A-2b"
+		}
+		sb.WriteString("\\ifdefined\\IfFormatAtLeastTF\\IfFormatAtLeastTF{2023-06-01}{%\n")
+		sb.WriteString("\\DocumentMetadata{" + keys + ", testphase=phase-III}%\n")
+		sb.WriteString("\\def\\atsmanagedpdf{}\\typeout{" + taggedMarker + "}}{}\\fi\n")
+	}
+	return sb.String()
+}
+
+// pdfaPreamble declares PDF/A-2b conformance in the XMP packet and embeds
+// the sRGB output intent from the colorprofiles package, using pdfTeX
+// primitives or dvipdfmx specials under xelatex
+const pdfaPreamble = `\hypersetup{pdfapart=2, pdfaconformance=B}
+\ifdefined\pdfomitcharset\pdfomitcharset=1\fi
+\ifdefined\pdfobj
+\immediate\pdfobj stream attr{/N 3} file{sRGB.icc}
+\pdfcatalog{/OutputIntents [<</Type /OutputIntent /S /GTS_PDFA1 /DestOutputProfile \the\pdflastobj\space 0 R /OutputConditionIdentifier (sRGB) /Info (sRGB IEC61966-2.1)>>]}
+\else
+\AtBeginDocument{\special{pdf:fstream @atsicc (sRGB.icc) <</N 3>>}%
+\special{pdf:put @catalog <</OutputIntents [<</Type /OutputIntent /S /GTS_PDFA1 /DestOutputProfile @atsicc /OutputConditionIdentifier (sRGB) /Info (sRGB IEC61966-2.1)>>]>>}}
+\fi
+`
+
+// buildMetadata sets the document information from the basic details and
+// skills, and embeds it as XMP through hyperxmp
+func (c *Compiler) buildMetadata(req *models.ResumeRequest, locale *i18n.Locale) string {
+	opts := req.PDF
+	if opts == nil {
+		opts = &models.PDFOptions{}
+	}
+
+	author := strings.TrimSpace(req.BasicDetails.FirstName + " " + req.BasicDetails.LastName)
+	title := strings.TrimSpace(opts.Title)
+	if title == "" {
+		title = author + " " + locale.Label("resume")
+	}
+	subject := strings.TrimSpace(opts.Subject)
+	if subject == "" {
+		subject = currentTitle(req.Sections)
+	}
+
+	return writeMetadata(title, author, subject, metadataKeywords(req.Sections, opts.Keywords), opts.PDFA, locale)
+}
+
+// writeMetadata emits the \hypersetup document information and the XMP and
+// PDF/A setup shared by all documents
+func writeMetadata(title, author, subject string, keywords []string, pdfa bool, locale *i18n.Locale) string {
+	fields := []string{
+		"pdftitle={" + EscapeString(title) + "}",
+		"pdfauthor={" + EscapeString(author) + "}",
+	}
+	if subject != "" {
+		fields = append(fields, "pdfsubject={"+EscapeString(subject)+"}")
+	}
+	if len(keywords) > 0 {
+		fields = append(fields, "pdfkeywords={"+strings.Join(EscapeStringSlice(keywords), ", ")+"}")
+	}
+	fields = append(fields, "pdfcreator={ATS Resume Maker}", "pdflang={"+locale.Code+"}")
+
+	var sb strings.Builder
+	sb.WriteString("\\hypersetup{" + strings.Join(fields, ", ") + "}\n")
+	sb.WriteString("\\ifdefined\\atsmanagedpdf\\else\n")
+	sb.WriteString("\\usepackage{hyperxmp}\n")
+	if pdfa {
+		sb.WriteString(pdfaPreamble)
+	}
+	sb.WriteString("\\fi\n")
+	return sb.String()
+}
+
+// currentTitle returns the first role listed in the first experience
+// section, in rendered order
+func currentTitle(sections []models.Section) string {
+	for _, section := range sections {
+		if section.Type != "experience" {
+			continue
+		}
+		content := section.Content
+		if section.Sort == SortReverseChronological {
+			content = sortEntries(content)
+		}
+		var data models.ExperienceContent
+		if err := models.DecodeContent(content, &data); err != nil || len(data.Entries) == 0 {
+			return ""
+		}
+		entry := data.Entries[0]
+		if len(entry.Positions) > 0 {
+			return strings.TrimSpace(entry.Positions[0].Title)
+		}
+		return strings.TrimSpace(entry.Title)
+	}
+	return ""
+}
+
+// metadataKeywords lists the resume's skills followed by the extra keywords,
+// dropping keywords that name an already listed skill
+func metadataKeywords(sections []models.Section, extra []string) []string {
+	var keywords []string
+	seen := make(map[string]bool)
+	add := func(k string) {
+		k = strings.TrimSpace(k)
+		key := strings.ToLower(skills.Canonical(k))
+		if k == "" || seen[key] || len(keywords) >= maxKeywords {
+			return
+		}
+		seen[key] = true
+		keywords = append(keywords, k)
+	}
+
+	for _, section := range sections {
+		if section.Type != "tech_skills" {
+			continue
+		}
+		var data models.TechSkillsContent
+		if err := models.DecodeContent(section.Content, &data); err != nil {
+			continue
+		}
+		for _, group := range skills.FromCategories(data.Categories, data.NormalizeSpelling) {
+			for _, name := range skills.Names(group.Skills) {
+				add(name)
+			}
+		}
+	}
+	for _, k := range extra {
+		add(k)
+	}
+	return keywords
+}
@@ -0,0 +1,147 @@
+package latex
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// metricsPreamble defines the position markers written into the pdflatex log.
+// \pdfsavepos records the position at shipout, so the deferred \write sees the
+// final page number and vertical offset (in sp from the bottom of the page).
+const metricsPreamble = `\newlinechar=` + "`" + `\^^J
+\newcommand{\atsmark}[1]{\pdfsavepos\write-1{^^JATSMARK:#1:\thepage:\the\pdflastypos}}
+\newcommand{\atsgeometry}{\typeout{^^JATSGEOM:\the\pdfpageheight:\the\dimexpr1in+\topmargin+\headheight+\headsep\relax:\the\textheight:\the\baselineskip}}
+`
+
+var (
+	markRegex     = regexp.MustCompile(`ATSMARK:(start|end):(\d+|doc):(\d+):(-?\d+)`)
+	geometryRegex = regexp.MustCompile(`ATSGEOM:([\d.]+)pt:([\d.]+)pt:([\d.]+)pt:([\d.]+)pt`)
+	boxRegex      = regexp.MustCompile(`^(Overfull|Underfull) \\(hbox|vbox) \(([^)]*)\)\s*(.*)$`)
+	pagesRegex    = regexp.MustCompile(`Output written on .*\((\d+) pages?`)
+)
+
+type markPosition struct {
+	page int
+	y    float64 // Points from the bottom of the page
+}
+
+// pageGeometry holds the text area dimensions reported by pdflatex, in points
+type pageGeometry struct {
+	textTop    float64 // Distance of the text area top from the bottom of the page
+	textHeight float64
+	baseline   float64
+}
+
+func (g pageGeometry) textBottom() float64 {
+	return g.textTop - g.textHeight
+}
+
+// extent returns the vertical distance between two marks, spanning page breaks
+func (g pageGeometry) extent(start, end markPosition) float64 {
+	if start.page == end.page {
+		return start.y - end.y
+	}
+	height := start.y - g.textBottom()
+	height += float64(end.page-start.page-1) * g.textHeight
+	height += g.textTop - end.y
+	return height
+}
+
+// parseLayoutMetrics extracts page, box and section metrics from a pdflatex log
+func parseLayoutMetrics(log string, sections []models.Section) *models.LayoutMetrics {
+	metrics := &models.LayoutMetrics{}
+
+	var geom pageGeometry
+	haveGeom := false
+	starts := make(map[string]markPosition)
+	ends := make(map[string]markPosition)
+
+	for _, line := range strings.Split(log, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if m := markRegex.FindStringSubmatch(line); m != nil {
+			page, _ := strconv.Atoi(m[3])
+			sp, _ := strconv.ParseFloat(m[4], 64)
+			pos := markPosition{page: page, y: sp / 65536}
+			if m[1] == "start" {
+				starts[m[2]] = pos
+			} else {
+				ends[m[2]] = pos
+			}
+			if page > metrics.PageCount {
+				metrics.PageCount = page
+			}
+			continue
+		}
+
+		if m := geometryRegex.FindStringSubmatch(line); m != nil {
+			pageHeight, _ := strconv.ParseFloat(m[1], 64)
+			topOffset, _ := strconv.ParseFloat(m[2], 64)
+			geom.textTop = pageHeight - topOffset
+			geom.textHeight, _ = strconv.ParseFloat(m[3], 64)
+			geom.baseline, _ = strconv.ParseFloat(m[4], 64)
+			haveGeom = geom.textHeight > 0 && geom.baseline > 0
+			continue
+		}
+
+		if m := boxRegex.FindStringSubmatch(line); m != nil {
+			warning := models.BoxWarning{
+				Kind:     strings.ToLower(m[1]),
+				Box:      m[2],
+				Detail:   m[3],
+				Location: strings.TrimSpace(m[4]),
+			}
+			if warning.Kind == "overfull" {
+				metrics.OverfullBoxes++
+			} else {
+				metrics.UnderfullBoxes++
+			}
+			metrics.Warnings = append(metrics.Warnings, warning)
+			continue
+		}
+
+		if m := pagesRegex.FindStringSubmatch(line); m != nil {
+			metrics.PageCount, _ = strconv.Atoi(m[1])
+		}
+	}
+
+	if !haveGeom {
+		return metrics
+	}
+
+	// Fill of the last page, measured from the top of its text area
+	if end, ok := ends["doc"]; ok {
+		used := math.Max(0, geom.textTop-end.y)
+		metrics.LastPageFill = round(math.Min(100, used/geom.textHeight*100))
+		if metrics.PageCount > 1 {
+			metrics.OverflowLines = int(math.Ceil(used / geom.baseline))
+		}
+	}
+
+	for i, section := range sections {
+		key := strconv.Itoa(i)
+		start, okStart := starts[key]
+		end, okEnd := ends[key]
+		if !okStart || !okEnd {
+			continue
+		}
+		height := geom.extent(start, end)
+		metrics.Sections = append(metrics.Sections, models.SectionExtent{
+			Type:      section.Type,
+			StartPage: start.page,
+			EndPage:   end.page,
+			Height:    round(height),
+			Lines:     round(height / geom.baseline),
+		})
+	}
+
+	return metrics
+}
+
+func round(f float64) float64 {
+	return math.Round(f*10) / 10
+}
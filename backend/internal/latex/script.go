@@ -0,0 +1,47 @@
+package latex
+
+import (
+	"regexp"
+	"strings"
+)
+
+// devanagariRun matches runs of Devanagari text, including the spaces and
+// danda punctuation between words
+var devanagariRun = regexp.MustCompile(`[\x{0900}-\x{097F}]+(?:[ \x{0964}\x{0965}]+[\x{0900}-\x{097F}]+)*`)
+
+// devanagariPreamble loads a system font covering Devanagari. It requires
+// xelatex, since pdflatex cannot shape complex scripts.
+const devanagariPreamble = `\usepackage{fontspec}
+\newfontfamily\devanagarifont[Script=Devanagari]{Noto Sans Devanagari}
+`
+
+// pdflatexPreamble sets the T1 font encoding for pdflatex, so accented
+// letters such as the É in "EXPÉRIENCE" are single glyphs that extract as one
+// character rather than a letter and a separate accent. Latin Modern
+// provides the T1 fonts as outlines.
+const pdflatexPreamble = `\usepackage[T1]{fontenc}
+\usepackage{lmodern}
+`
+
+// hasDevanagari reports whether any of the strings contain Devanagari text
+func hasDevanagari(values ...string) bool {
+	for _, v := range values {
+		if devanagariRun.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapDevanagari switches each Devanagari run to the Devanagari font
+func wrapDevanagari(s string) string {
+	return devanagariRun.ReplaceAllString(s, `{\devanagarifont $0}`)
+}
+
+// engineFor picks the TeX engine able to typeset the generated document
+func engineFor(latexContent string) string {
+	if strings.Contains(latexContent, `\newfontfamily\devanagarifont`) {
+		return "xelatex"
+	}
+	return "pdflatex"
+}
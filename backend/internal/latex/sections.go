@@ -0,0 +1,234 @@
+package latex
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/links"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// webURL returns the normalized form of an entry's URL, or "" when it is
+// missing or invalid
+func webURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	link, err := links.Normalize("", "", raw)
+	if err != nil {
+		return ""
+	}
+	return link.URL
+}
+
+func (c *Compiler) buildCertifications(ctx *renderContext, heading string, content interface{}) string {
+	var data models.CertificationsContent
+	if err := models.DecodeContent(content, &data); err != nil || len(data.Entries) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
+
+	for _, e := range data.Entries {
+		line := fmt.Sprintf("{\\bf %s}", EscapeString(e.Name))
+		if e.Issuer != "" {
+			line += ", " + EscapeString(e.Issuer)
+		}
+		url := webURL(e.URL)
+		switch {
+		case e.CredentialID != "" && url != "":
+			line += " (" + FormatURL(url, e.CredentialID) + ")"
+		case e.CredentialID != "":
+			line += " (" + EscapeString(e.CredentialID) + ")"
+		case url != "":
+			line += " " + FormatURL(url, "(Link)")
+		}
+		if dateStr := ctx.dateRange(e.Date, e.ExpiryDate, false); dateStr != "" {
+			line += " \\hfill {" + dateStr + "}"
+		}
+		sb.WriteString(line + "\\\\\n")
+	}
+
+	sb.WriteString("\n\\end{rSection}\n\n")
+	return sb.String()
+}
+
+func (c *Compiler) buildAwards(ctx *renderContext, heading string, content interface{}) string {
+	var data models.AwardsContent
+	if err := models.DecodeContent(content, &data); err != nil || len(data.Entries) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
+
+	for _, e := range data.Entries {
+		line := fmt.Sprintf("{\\bf %s}", EscapeString(e.Title))
+		if e.Issuer != "" {
+			line += ", " + EscapeString(e.Issuer)
+		}
+		if e.Date != "" {
+			line += " \\hfill {" + ctx.date(e.Date) + "}"
+		}
+		sb.WriteString(line + "\\\\\n")
+		if e.Description != "" {
+			sb.WriteString(EscapeString(e.Description) + "\\\\\n")
+		}
+	}
+
+	sb.WriteString("\n\\end{rSection}\n\n")
+	return sb.String()
+}
+
+func (c *Compiler) buildPublications(ctx *renderContext, heading string, content interface{}) string {
+	var data models.PublicationsContent
+	if err := models.DecodeContent(content, &data); err != nil || len(data.Entries) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
+
+	for _, e := range data.Entries {
+		// Citation style: Authors. Title. Venue, Year. DOI
+		var parts []string
+		if len(e.Authors) > 0 {
+			parts = append(parts, EscapeString(strings.Join(e.Authors, ", "))+".")
+		}
+		parts = append(parts, fmt.Sprintf("{\\bf %s}.", EscapeString(e.Title)))
+
+		venue := EscapeString(e.Venue)
+		if venue != "" {
+			venue = "\\textit{" + venue + "}"
+		}
+		if e.Year != "" {
+			if venue != "" {
+				venue += ", "
+			}
+			venue += EscapeString(e.Year)
+		}
+		if venue != "" {
+			parts = append(parts, venue+".")
+		}
+
+		if doi, err := links.NormalizeDOI(e.DOI); err == nil {
+			parts = append(parts, FormatURL("https://doi.org/"+doi, "doi:"+doi))
+		} else if url := webURL(e.URL); url != "" {
+			parts = append(parts, FormatURL(url, "(Link)"))
+		}
+
+		sb.WriteString(strings.Join(parts, " ") + "\n\n")
+	}
+
+	sb.WriteString("\\end{rSection}\n\n")
+	return sb.String()
+}
+
+func (c *Compiler) buildLanguages(ctx *renderContext, heading string, content interface{}) string {
+	var data models.LanguagesContent
+	if err := models.DecodeContent(content, &data); err != nil || len(data.Entries) == 0 {
+		return ""
+	}
+
+	var items []string
+	for _, e := range data.Entries {
+		item := fmt.Sprintf("{\\bf %s}", EscapeString(e.Name))
+		if e.Proficiency != "" {
+			item += " (" + EscapeString(ctx.locale.ProficiencyLabel(e.Proficiency)) + ")"
+		}
+		items = append(items, item)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
+	sb.WriteString(strings.Join(items, ", ") + "\n\n")
+	sb.WriteString("\\end{rSection}\n\n")
+	return sb.String()
+}
+
+func (c *Compiler) buildInterests(ctx *renderContext, heading string, content interface{}) string {
+	var data models.InterestsContent
+	if err := models.DecodeContent(content, &data); err != nil {
+		return ""
+	}
+
+	var items []string
+	for _, item := range data.Items {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, EscapeString(item))
+		}
+	}
+	if len(items) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
+	sb.WriteString(strings.Join(items, ", ") + "\n\n")
+	sb.WriteString("\\end{rSection}\n\n")
+	return sb.String()
+}
+
+// buildCustom renders a user-defined section with the same primitives as the
+// built-in sections: bullets, a skills-style table, experience-style entries
+// or a summary-style paragraph
+func (c *Compiler) buildCustom(ctx *renderContext, heading string, content interface{}) string {
+	var data models.CustomContent
+	if err := models.DecodeContent(content, &data); err != nil || heading == "" {
+		return ""
+	}
+
+	var body strings.Builder
+	switch data.Layout {
+	case models.CustomLayoutBullets:
+		var bullets []interface{}
+		for _, b := range data.Bullets {
+			bullets = append(bullets, b)
+		}
+		body.WriteString(buildBullets(bullets))
+
+	case models.CustomLayoutTable:
+		if len(data.Rows) == 0 {
+			break
+		}
+		body.WriteString("\\begin{tabular}{ @{} >{\\bfseries}l @{\\hspace{6ex}} l }\n")
+		for _, row := range data.Rows {
+			body.WriteString(EscapeString(row.Key) + " & " + EscapeString(row.Value) + "\\\\\n")
+		}
+		body.WriteString("\\end{tabular}\\\\\n")
+
+	case models.CustomLayoutEntries:
+		for _, e := range data.Entries {
+			body.WriteString(fmt.Sprintf("\\textbf{%s} \\hfill %s",
+				EscapeString(e.Title), ctx.dateRange(e.StartDate, e.EndDate, e.Current)))
+			if e.Subtitle != "" || e.Location != "" {
+				body.WriteString(fmt.Sprintf("\\\\\n%s \\hfill \\textit{%s}",
+					EscapeString(e.Subtitle), EscapeString(e.Location)))
+			}
+			body.WriteString("\n")
+
+			var bullets []interface{}
+			for _, b := range e.Bullets {
+				bullets = append(bullets, b)
+			}
+			body.WriteString(buildBullets(bullets))
+			body.WriteString("\n")
+		}
+
+	case models.CustomLayoutParagraph:
+		if strings.TrimSpace(data.Text) != "" {
+			body.WriteString("{" + EscapeString(data.Text) + "}\n\n")
+		}
+	}
+
+	if body.Len() == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\\begin{rSection}{" + EscapeString(heading) + "}\n\n")
+	sb.WriteString(body.String())
+	sb.WriteString("\\end{rSection}\n\n")
+	return sb.String()
+}
@@ -0,0 +1,264 @@
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Known link kinds
+const (
+	KindGitHub        = "github"
+	KindLinkedIn      = "linkedin"
+	KindStackOverflow = "stackoverflow"
+	KindScholar       = "scholar"
+	KindORCID         = "orcid"
+	KindMastodon      = "mastodon"
+	KindPersonal      = "personal"
+)
+
+// Link is a validated contact link ready for rendering
+type Link struct {
+	Kind    string
+	URL     string // Canonical URL used as the hyperlink target
+	Display string // Text shown in the PDF
+}
+
+var (
+	orcidRegex    = regexp.MustCompile(`^(\d{4})-?(\d{4})-?(\d{4})-?(\d{3}[\dX])$`)
+	mastodonRegex = regexp.MustCompile(`^@?([A-Za-z0-9_]+)@([A-Za-z0-9.-]+\.[A-Za-z]{2,})$`)
+	mastodonUser  = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+	scholarRegex  = regexp.MustCompile(`^[A-Za-z0-9_-]{12}$`)
+	soUserRegex   = regexp.MustCompile(`^/users/(\d+)(/[^/]*)?/?$`)
+	handleRegex   = regexp.MustCompile(`^/((in|company)/)?[A-Za-z0-9_.%-]+/?$`)
+)
+
+// Normalize validates a link of the given kind and derives its canonical URL
+// and display text. A non-empty label replaces the canonical display text.
+// Unknown kinds are treated as generic web links.
+func Normalize(kind, label, raw string) (Link, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Link{}, fmt.Errorf("URL is required")
+	}
+
+	var link Link
+	var err error
+	switch kind {
+	case KindORCID:
+		link, err = normalizeORCID(raw)
+	case KindMastodon:
+		link, err = normalizeMastodon(raw)
+	case KindScholar:
+		link, err = normalizeScholar(raw)
+	case KindStackOverflow:
+		link, err = normalizeStackOverflow(raw)
+	case KindGitHub:
+		link, err = normalizeProfile(raw, "github.com")
+	case KindLinkedIn:
+		link, err = normalizeProfile(raw, "linkedin.com")
+	default:
+		link, err = normalizeWeb(raw)
+	}
+	if err != nil {
+		return Link{}, err
+	}
+
+	link.Kind = kind
+	if label = strings.TrimSpace(label); label != "" {
+		link.Display = label
+	}
+	return link, nil
+}
+
+// parseWeb parses an http(s) URL, adding the scheme when omitted
+func parseWeb(raw string) (*url.URL, error) {
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || !strings.Contains(u.Host, ".") {
+		return nil, fmt.Errorf("%q is not a valid web address", raw)
+	}
+	return u, nil
+}
+
+// stripScheme returns the URL without its scheme, "www." prefix or trailing slash
+func stripScheme(u *url.URL) string {
+	display := strings.TrimPrefix(u.Host, "www.") + u.EscapedPath()
+	if u.RawQuery != "" {
+		display += "?" + u.RawQuery
+	}
+	return strings.TrimSuffix(display, "/")
+}
+
+func normalizeWeb(raw string) (Link, error) {
+	u, err := parseWeb(raw)
+	if err != nil {
+		return Link{}, err
+	}
+	return Link{URL: u.String(), Display: stripScheme(u)}, nil
+}
+
+// normalizeProfile validates a single-handle profile URL on the given host
+func normalizeProfile(raw, host string) (Link, error) {
+	u, err := parseWeb(raw)
+	if err != nil {
+		return Link{}, err
+	}
+	if strings.TrimPrefix(u.Host, "www.") != host || !handleRegex.MatchString(u.Path) {
+		return Link{}, fmt.Errorf("%q is not a %s profile URL", raw, host)
+	}
+	u.Host = host
+	u.Scheme = "https"
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return Link{URL: u.String(), Display: stripScheme(u)}, nil
+}
+
+func normalizeStackOverflow(raw string) (Link, error) {
+	u, err := parseWeb(raw)
+	if err != nil {
+		return Link{}, err
+	}
+	m := soUserRegex.FindStringSubmatch(u.Path)
+	if strings.TrimPrefix(u.Host, "www.") != "stackoverflow.com" || m == nil {
+		return Link{}, fmt.Errorf("%q is not a Stack Overflow profile URL", raw)
+	}
+	canonical := "stackoverflow.com/users/" + m[1]
+	return Link{URL: "https://" + canonical, Display: canonical}, nil
+}
+
+// normalizeScholar accepts a Google Scholar profile URL or a bare user ID
+func normalizeScholar(raw string) (Link, error) {
+	id := raw
+	if !scholarRegex.MatchString(raw) {
+		u, err := parseWeb(raw)
+		if err != nil {
+			return Link{}, err
+		}
+		id = u.Query().Get("user")
+		if u.Host != "scholar.google.com" || !scholarRegex.MatchString(id) {
+			return Link{}, fmt.Errorf("%q is not a Google Scholar profile URL", raw)
+		}
+	}
+	canonical := "scholar.google.com/citations?user=" + id
+	return Link{URL: "https://" + canonical, Display: canonical}, nil
+}
+
+// normalizeORCID accepts an ORCID iD or orcid.org URL and verifies its checksum
+func normalizeORCID(raw string) (Link, error) {
+	id := strings.TrimSuffix(raw, "/")
+	for _, prefix := range []string{"https://", "http://", "orcid.org/", "www.orcid.org/"} {
+		id = strings.TrimPrefix(id, prefix)
+	}
+	m := orcidRegex.FindStringSubmatch(strings.ToUpper(id))
+	if m == nil {
+		return Link{}, fmt.Errorf("%q is not a valid ORCID iD", raw)
+	}
+	id = strings.Join(m[1:], "-")
+	if !orcidChecksumValid(strings.ReplaceAll(id, "-", "")) {
+		return Link{}, fmt.Errorf("%q has an invalid ORCID checksum", raw)
+	}
+	return Link{URL: "https://orcid.org/" + id, Display: "orcid.org/" + id}, nil
+}
+
+// orcidChecksumValid verifies the ISO 7064 MOD 11-2 check digit
+func orcidChecksumValid(digits string) bool {
+	total := 0
+	for _, r := range digits[:len(digits)-1] {
+		total = (total + int(r-'0')) * 2
+	}
+	check := (12 - total%11) % 11
+	want := byte('0' + check)
+	if check == 10 {
+		want = 'X'
+	}
+	return digits[len(digits)-1] == want
+}
+
+// normalizeMastodon accepts "@user@instance" handles or https://instance/@user URLs
+func normalizeMastodon(raw string) (Link, error) {
+	var user, instance string
+	if m := mastodonRegex.FindStringSubmatch(raw); m != nil {
+		user, instance = m[1], strings.ToLower(m[2])
+	} else {
+		u, err := parseWeb(raw)
+		if err != nil {
+			return Link{}, err
+		}
+		path := strings.TrimSuffix(u.Path, "/")
+		if !strings.HasPrefix(path, "/@") || !mastodonUser.MatchString(path[2:]) {
+			return Link{}, fmt.Errorf("%q is not a Mastodon profile", raw)
+		}
+		user, instance = path[2:], strings.ToLower(u.Host)
+	}
+	return Link{
+		URL:     "https://" + instance + "/@" + user,
+		Display: "@" + user + "@" + instance,
+	}, nil
+}
+
+// doiRegex accepts the DOI suffix characters Crossref recommends, which
+// excludes braces and backslashes that would break out of an \href
+var doiRegex = regexp.MustCompile(`^10\.\d{4,9}/[-._;()/:A-Za-z0-9]+$`)
+
+// NormalizeDOI accepts a bare DOI, a "doi:" reference or a doi.org URL and
+// returns the bare DOI, e.g. "10.1145/3368089.3409741"
+func NormalizeDOI(raw string) (string, error) {
+	doi := strings.TrimSpace(raw)
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi.org/", "doi:"} {
+		if len(doi) >= len(prefix) && strings.EqualFold(doi[:len(prefix)], prefix) {
+			doi = strings.TrimSpace(doi[len(prefix):])
+			break
+		}
+	}
+	if !doiRegex.MatchString(doi) {
+		return "", fmt.Errorf("%q is not a valid DOI", raw)
+	}
+	return doi, nil
+}
+
+// projectLinkKinds maps the accepted project link kinds to their default
+// display text
+var projectLinkKinds = map[string]string{
+	"repo":  "Repo",
+	"demo":  "Demo",
+	"paper": "Paper",
+	"video": "Video",
+	"docs":  "Docs",
+	"other": "Link",
+}
+
+// NormalizeProjectLink validates a project link. Paper links may be given as
+// a DOI. Without a label, known kinds display their kind name, e.g. "Demo".
+func NormalizeProjectLink(kind, label, raw string) (Link, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		kind = "other"
+	}
+	display, ok := projectLinkKinds[kind]
+	if !ok {
+		return Link{}, fmt.Errorf("unsupported project link kind %q", kind)
+	}
+
+	if kind == "paper" {
+		if doi, err := NormalizeDOI(raw); err == nil {
+			raw = "https://doi.org/" + doi
+		}
+	}
+	link, err := normalizeWeb(strings.TrimSpace(raw))
+	if err != nil {
+		return Link{}, err
+	}
+
+	link.Kind = kind
+	link.Display = display
+	if label = strings.TrimSpace(label); label != "" {
+		link.Display = label
+	}
+	return link, nil
+}
@@ -0,0 +1,123 @@
+package links
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		kind, label, raw string
+		url, display     string
+	}{
+		{"github", "", "github.com/octocat", "https://github.com/octocat", "github.com/octocat"},
+		{"github", "", "https://www.github.com/octocat/", "https://github.com/octocat", "github.com/octocat"},
+		{"linkedin", "", "http://linkedin.com/in/jane-doe?trk=x", "https://linkedin.com/in/jane-doe", "linkedin.com/in/jane-doe"},
+		{"stackoverflow", "", "https://stackoverflow.com/users/22656/jon-skeet", "https://stackoverflow.com/users/22656", "stackoverflow.com/users/22656"},
+		{"scholar", "", "https://scholar.google.com/citations?user=qc6CJjYAAAAJ&hl=en", "https://scholar.google.com/citations?user=qc6CJjYAAAAJ", "scholar.google.com/citations?user=qc6CJjYAAAAJ"},
+		{"scholar", "", "qc6CJjYAAAAJ", "https://scholar.google.com/citations?user=qc6CJjYAAAAJ", "scholar.google.com/citations?user=qc6CJjYAAAAJ"},
+		{"orcid", "", "0000-0002-1825-0097", "https://orcid.org/0000-0002-1825-0097", "orcid.org/0000-0002-1825-0097"},
+		{"orcid", "", "https://orcid.org/0000000218250097", "https://orcid.org/0000-0002-1825-0097", "orcid.org/0000-0002-1825-0097"},
+		{"orcid", "", "0000-0002-9079-593x", "https://orcid.org/0000-0002-9079-593X", "orcid.org/0000-0002-9079-593X"},
+		{"mastodon", "", "@jane@Mastodon.Social", "https://mastodon.social/@jane", "@jane@mastodon.social"},
+		{"mastodon", "", "https://fosstodon.org/@jane_doe/", "https://fosstodon.org/@jane_doe", "@jane_doe@fosstodon.org"},
+		{"personal", "", "janedoe.dev", "https://janedoe.dev", "janedoe.dev"},
+		{"personal", "Portfolio", "https://www.janedoe.dev/work/", "https://www.janedoe.dev/work/", "Portfolio"},
+		{"blog", "", "http://blog.example.com/", "http://blog.example.com/", "blog.example.com"},
+	}
+	for _, tt := range tests {
+		link, err := Normalize(tt.kind, tt.label, tt.raw)
+		if err != nil {
+			t.Errorf("Normalize(%q, %q) error = %v", tt.kind, tt.raw, err)
+			continue
+		}
+		if link.URL != tt.url || link.Display != tt.display {
+			t.Errorf("Normalize(%q, %q) = %q, %q, want %q, %q", tt.kind, tt.raw, link.URL, link.Display, tt.url, tt.display)
+		}
+	}
+}
+
+func TestNormalizeInvalid(t *testing.T) {
+	tests := []struct{ kind, raw string }{
+		{"github", ""},
+		{"github", "https://gitlab.com/octocat"},
+		{"github", "https://github.com/octocat/repo"},
+		{"linkedin", "https://linkedin.com/in/jane/details"},
+		{"stackoverflow", "https://stackoverflow.com/questions/1"},
+		{"scholar", "https://example.com/citations?user=qc6CJjYAAAAJ"},
+		{"orcid", "0000-0002-1825-0098"}, // Bad check digit
+		{"orcid", "0000-0002-1825"},
+		{"mastodon", "jane@localhost"},
+		{"mastodon", "https://mastodon.social/jane"},
+		{"mastodon", "https://mastodon.social/@jane/123"},
+		{"mastodon", "https://mastodon.social/@ja%7Dne"},
+		{"mastodon", `https://mastodon.social/@ja\ne`},
+		{"personal", "ftp://example.com"},
+		{"personal", "localhost"},
+	}
+	for _, tt := range tests {
+		if link, err := Normalize(tt.kind, "", tt.raw); err == nil {
+			t.Errorf("Normalize(%q, %q) = %+v, want an error", tt.kind, tt.raw, link)
+		}
+	}
+}
+
+func TestORCIDChecksum(t *testing.T) {
+	tests := map[string]bool{
+		"0000000218250097": true,
+		"0000000151093700": true,
+		"000000029079593X": true,
+		"0000000218250098": false,
+		"0000000151093701": false,
+		"0000000290795930": false,
+	}
+	for digits, want := range tests {
+		if got := orcidChecksumValid(digits); got != want {
+			t.Errorf("orcidChecksumValid(%q) = %v, want %v", digits, got, want)
+		}
+	}
+}
+
+func TestNormalizeDOI(t *testing.T) {
+	tests := map[string]string{
+		"10.1145/3368089.3409741":                   "10.1145/3368089.3409741",
+		"doi:10.1000/xyz123":                        "10.1000/xyz123",
+		"https://doi.org/10.1038/nphys1170":         "10.1038/nphys1170",
+		"HTTP://DX.DOI.ORG/10.1002/(SICI)1097-4571": "10.1002/(SICI)1097-4571",
+	}
+	for in, want := range tests {
+		got, err := NormalizeDOI(in)
+		if err != nil || got != want {
+			t.Errorf("NormalizeDOI(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{
+		"",
+		"10.12/short-registrant",
+		"11.1145/3368089",
+		"10.1145/",
+		"10.1145/a}{\\evil",
+		"10.1145/with space",
+	} {
+		if got, err := NormalizeDOI(in); err == nil {
+			t.Errorf("NormalizeDOI(%q) = %q, want an error", in, got)
+		}
+	}
+}
+
+func TestNormalizeProjectLink(t *testing.T) {
+	link, err := NormalizeProjectLink("paper", "", "doi:10.1145/3368089.3409741")
+	if err != nil {
+		t.Fatalf("NormalizeProjectLink error = %v", err)
+	}
+	if link.URL != "https://doi.org/10.1145/3368089.3409741" || link.Display != "Paper" {
+		t.Errorf("NormalizeProjectLink = %+v", link)
+	}
+
+	link, err = NormalizeProjectLink("", "Live site", "example.com")
+	if err != nil || link.Kind != "other" || link.Display != "Live site" {
+		t.Errorf("NormalizeProjectLink without kind = %+v, %v", link, err)
+	}
+
+	if _, err := NormalizeProjectLink("slides", "", "example.com"); err == nil {
+		t.Error("NormalizeProjectLink accepted an unknown kind")
+	}
+}
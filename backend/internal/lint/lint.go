@@ -0,0 +1,269 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/dates"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// Severities, from most to least serious
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Rule IDs
+const (
+	RuleBulletMetric    = "bullet-metric"
+	RuleWeakOpener      = "weak-opener"
+	RuleFirstPerson     = "first-person"
+	RuleBulletLength    = "bullet-length"
+	RuleTense           = "tense"
+	RuleMissingSection  = "missing-section"
+	RuleDuplicateBullet = "duplicate-bullet"
+	RuleDateFormat      = "date-format"
+)
+
+// DefaultMaxBulletWords is the bullet length limit when the config sets none
+const DefaultMaxBulletWords = 30
+
+// DefaultRequiredSections are the section types expected when the config
+// lists none
+var DefaultRequiredSections = []string{"experience", "education", "tech_skills"}
+
+// rule is a single check over a parsed resume
+type rule struct {
+	id       string
+	severity string
+	check    func(r *resume, cfg *models.LintConfig) []finding
+}
+
+// finding is an issue before its severity is resolved
+type finding struct {
+	message  string
+	location *models.LintLocation
+}
+
+// rules lists every rule in the order their issues are reported
+var rules = []rule{
+	{RuleMissingSection, SeverityError, checkMissingSections},
+	{RuleWeakOpener, SeverityWarning, checkWeakOpeners},
+	{RuleFirstPerson, SeverityWarning, checkFirstPerson},
+	{RuleTense, SeverityWarning, checkTense},
+	{RuleDuplicateBullet, SeverityWarning, checkDuplicateBullets},
+	{RuleBulletMetric, SeverityInfo, checkMetrics},
+	{RuleBulletLength, SeverityInfo, checkBulletLength},
+	{RuleDateFormat, SeverityInfo, checkDateFormats},
+}
+
+// Rules returns the IDs of all rules
+func Rules() []string {
+	ids := make([]string, len(rules))
+	for i, r := range rules {
+		ids[i] = r.id
+	}
+	return ids
+}
+
+// ValidateConfig checks a lint config for unknown rules and severities
+func ValidateConfig(cfg *models.LintConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+
+	var errors []string
+	ids := Rules()
+	for _, id := range cfg.Disable {
+		if !slices.Contains(ids, id) {
+			errors = append(errors, fmt.Sprintf("Unknown lint rule %q", id))
+		}
+	}
+	for id, severity := range cfg.Severity {
+		if !slices.Contains(ids, id) {
+			errors = append(errors, fmt.Sprintf("Unknown lint rule %q", id))
+		}
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityInfo:
+		default:
+			errors = append(errors, fmt.Sprintf("Lint rule %q: severity must be %q, %q or %q", id, SeverityError, SeverityWarning, SeverityInfo))
+		}
+	}
+	if cfg.MaxBulletWords < 0 {
+		errors = append(errors, "Lint maxBulletWords must not be negative")
+	}
+	sort.Strings(errors) // Map iteration order is random
+	return errors
+}
+
+// Run checks a resume against the enabled rules. A nil config runs every
+// rule with its default settings. Issues are ordered by rule, then by
+// position in the resume.
+func Run(req *models.ResumeRequest, cfg *models.LintConfig) []models.LintIssue {
+	if cfg == nil {
+		cfg = &models.LintConfig{}
+	}
+	r := parse(req)
+
+	issues := []models.LintIssue{}
+	for _, rl := range rules {
+		if slices.Contains(cfg.Disable, rl.id) {
+			continue
+		}
+		severity := rl.severity
+		if s, ok := cfg.Severity[rl.id]; ok {
+			severity = s
+		}
+		for _, f := range rl.check(r, cfg) {
+			issues = append(issues, models.LintIssue{
+				Rule:     rl.id,
+				Severity: severity,
+				Message:  f.message,
+				Location: f.location,
+			})
+		}
+	}
+	return issues
+}
+
+// resume is the lintable text of a request, flattened out of its sections
+type resume struct {
+	sectionTypes []string
+	bullets      []bullet
+	prose        []prose
+	dates        []date
+}
+
+// bullet is a bullet point. Role bullets belong to a dated role and must
+// follow its tense.
+type bullet struct {
+	text     string
+	location *models.LintLocation
+	role     bool
+	current  bool
+}
+
+// prose is free text outside bullets, e.g. a paragraph summary
+type prose struct {
+	text     string
+	location *models.LintLocation
+}
+
+// date is a raw date string and where it was given
+type date struct {
+	value    string
+	location *models.LintLocation
+}
+
+// parse flattens the sections the rules look at. Content that fails to
+// decode is skipped; validation reports it.
+func parse(req *models.ResumeRequest) *resume {
+	r := &resume{}
+
+	for i, section := range req.Sections {
+		r.sectionTypes = append(r.sectionTypes, section.Type)
+		at := func(entry, position, bullet int, field string) *models.LintLocation {
+			return &models.LintLocation{Section: i + 1, SectionType: section.Type, Entry: entry, Position: position, Bullet: bullet, Field: field}
+		}
+		addRole := func(entry, position int, start, end string, current bool, bullets []string) {
+			r.dates = append(r.dates, date{start, at(entry, position, 0, "startDate")}, date{end, at(entry, position, 0, "endDate")})
+			if d, err := dates.Parse(end); err == nil && d.Present {
+				current = true
+			}
+			for k, text := range bullets {
+				r.bullets = append(r.bullets, bullet{text: text, location: at(entry, position, k+1, ""), role: true, current: current})
+			}
+		}
+
+		switch section.Type {
+		case "profile_summary":
+			var data models.ProfileSummaryContent
+			if models.DecodeContent(section.Content, &data) != nil {
+				continue
+			}
+			if strings.TrimSpace(data.Text) != "" {
+				r.prose = append(r.prose, prose{data.Text, at(0, 0, 0, "text")})
+			}
+			for k, text := range data.Bullets {
+				r.bullets = append(r.bullets, bullet{text: text, location: at(0, 0, k+1, "")})
+			}
+		case "experience":
+			var data models.ExperienceContent
+			if models.DecodeContent(section.Content, &data) != nil {
+				continue
+			}
+			for j, e := range data.Entries {
+				if len(e.Positions) == 0 {
+					addRole(j+1, 0, e.StartDate, e.EndDate, e.Current, e.Bullets)
+				}
+				for k, p := range e.Positions {
+					addRole(j+1, k+1, p.StartDate, p.EndDate, p.Current, p.Bullets)
+				}
+			}
+		case "volunteer":
+			var data models.VolunteerContent
+			if models.DecodeContent(section.Content, &data) != nil {
+				continue
+			}
+			for j, e := range data.Entries {
+				addRole(j+1, 0, e.StartDate, e.EndDate, e.Current, e.Bullets)
+			}
+		case "projects":
+			var data models.ProjectsContent
+			if models.DecodeContent(section.Content, &data) != nil {
+				continue
+			}
+			for j, e := range data.Entries {
+				r.dates = append(r.dates, date{e.Date, at(j+1, 0, 0, "date")})
+				for k, text := range e.Description {
+					r.bullets = append(r.bullets, bullet{text: text, location: at(j+1, 0, k+1, "")})
+				}
+			}
+		case "education":
+			var data models.EducationContent
+			if models.DecodeContent(section.Content, &data) != nil {
+				continue
+			}
+			for j, e := range data.Entries {
+				r.dates = append(r.dates, date{e.StartDate, at(j+1, 0, 0, "startDate")}, date{e.EndDate, at(j+1, 0, 0, "endDate")})
+			}
+		case "certifications":
+			var data models.CertificationsContent
+			if models.DecodeContent(section.Content, &data) != nil {
+				continue
+			}
+			for j, e := range data.Entries {
+				r.dates = append(r.dates, date{e.Date, at(j+1, 0, 0, "date")}, date{e.ExpiryDate, at(j+1, 0, 0, "expiryDate")})
+			}
+		case "awards":
+			var data models.AwardsContent
+			if models.DecodeContent(section.Content, &data) != nil {
+				continue
+			}
+			for j, e := range data.Entries {
+				r.dates = append(r.dates, date{e.Date, at(j+1, 0, 0, "date")})
+			}
+		}
+	}
+
+	return r
+}
+
+// describe renders a location for messages, e.g. "section 2, entry 1, bullet 3"
+func describe(loc *models.LintLocation) string {
+	parts := []string{fmt.Sprintf("section %d", loc.Section)}
+	if loc.Entry > 0 {
+		parts = append(parts, fmt.Sprintf("entry %d", loc.Entry))
+	}
+	if loc.Position > 0 {
+		parts = append(parts, fmt.Sprintf("position %d", loc.Position))
+	}
+	if loc.Bullet > 0 {
+		parts = append(parts, fmt.Sprintf("bullet %d", loc.Bullet))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,165 @@
+package lint
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// request decodes the sections of a test resume from JSON
+func request(t *testing.T, sections string) *models.ResumeRequest {
+	t.Helper()
+	var req models.ResumeRequest
+	if err := json.Unmarshal([]byte(`{"sections":`+sections+`}`), &req); err != nil {
+		t.Fatalf("invalid test resume: %v", err)
+	}
+	return &req
+}
+
+// only returns a config that runs just the given rule
+func only(id string) *models.LintConfig {
+	cfg := &models.LintConfig{RequiredSections: []string{}}
+	for _, other := range Rules() {
+		if other != id {
+			cfg.Disable = append(cfg.Disable, other)
+		}
+	}
+	return cfg
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		rule     string
+		sections string
+		want     []string // Messages, in order
+	}{
+		{
+			rule:     RuleMissingSection,
+			sections: `[{"type":"experience","content":{"entries":[]}}]`,
+			want:     []string{"Resume has no education section", "Resume has no skills section"},
+		},
+		{
+			rule: RuleWeakOpener,
+			sections: `[{"type":"experience","content":{"entries":[{"startDate":"2020-01","endDate":"2021-01",
+				"bullets":["Responsible for the billing service","Helped onboard 5 engineers","Helpers rotated weekly"]}]}}]`,
+			want: []string{
+				`Bullet starts with "Responsible for"; lead with a strong action verb instead`,
+				`Bullet starts with "Helped"; lead with a strong action verb instead`,
+			},
+		},
+		{
+			rule: RuleFirstPerson,
+			sections: `[{"type":"profile_summary","content":{"text":"I build reliable systems"}},
+				{"type":"projects","content":{"entries":[{"description":["Built our CI pipeline","Cut API latency"]}]}}]`,
+			want: []string{`Avoid first-person pronouns such as "I"`, `Avoid first-person pronouns such as "our"`},
+		},
+		{
+			rule: RuleTense,
+			sections: `[{"type":"experience","content":{"entries":[
+				{"startDate":"2022-01","current":true,"bullets":["Led the platform team","Lead the platform team"]},
+				{"startDate":"2019-01","endDate":"2021-12","bullets":["Manage a team of 4","Managed a team of 4"]}]}}]`,
+			want: []string{
+				`Current role bullet starts with past tense "Led"; use present tense`,
+				`Past role bullet starts with present tense "Manage"; use past tense`,
+			},
+		},
+		{
+			rule: RuleDuplicateBullet,
+			sections: `[{"type":"experience","content":{"entries":[
+				{"startDate":"2022-01","endDate":"2023-01","bullets":["Shipped the v2 API."]},
+				{"startDate":"2020-01","endDate":"2021-01","bullets":["shipped  the v2 API"]}]}}]`,
+			want: []string{"Bullet duplicates section 1, entry 1, bullet 1"},
+		},
+		{
+			rule: RuleBulletMetric,
+			sections: `[{"type":"profile_summary","content":{"bullets":["Seasoned engineer"]}},
+				{"type":"projects","content":{"entries":[{"description":["Cut costs by 30%","Rewrote the scheduler"]}]}}]`,
+			want: []string{"Bullet has no metric; quantify the impact where possible"},
+		},
+		{
+			rule: RuleBulletLength,
+			sections: `[{"type":"projects","content":{"entries":[{"description":[
+				"one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen seventeen eighteen nineteen twenty twenty-one twenty-two twenty-three twenty-four twenty-five twenty-six twenty-seven twenty-eight twenty-nine thirty thirty-one",
+				"Short bullet"]}]}}]`,
+			want: []string{"Bullet is 31 words long; keep bullets under 30 words"},
+		},
+		{
+			rule: RuleDateFormat,
+			sections: `[{"type":"experience","content":{"entries":[
+				{"startDate":"Jan 2020","endDate":"Mar 2021"},
+				{"startDate":"2018-04","endDate":"Dec 2019"},
+				{"startDate":"2016","endDate":"Present"}]}}]`,
+			want: []string{`Date "2018-04" is written like "2020-01" while most dates look like "Jan 2020"`},
+		},
+		{
+			rule: RuleDateFormat,
+			sections: `[{"type":"education","content":{"entries":[
+				{"startDate":"Sep 2013","endDate":"May 2017","gpa":3.8,"gpaScale":4},
+				{"startDate":"Sep 2017","endDate":"2019-06","gpa":"3.9"}]}}]`,
+			want: []string{`Date "2019-06" is written like "2020-01" while most dates look like "Jan 2020"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			cfg := only(tt.rule)
+			if tt.rule == RuleMissingSection {
+				cfg.RequiredSections = nil
+			}
+			var got []string
+			for _, issue := range Run(request(t, tt.sections), cfg) {
+				if issue.Rule != tt.rule {
+					t.Errorf("disabled rule %q reported an issue", issue.Rule)
+				}
+				got = append(got, issue.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("issues = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunConfig(t *testing.T) {
+	req := request(t, `[{"type":"projects","content":{"entries":[{"description":["Rewrote the scheduler in four short words"]}]}}]`)
+	cfg := only(RuleBulletLength)
+	cfg.MaxBulletWords = 5
+	cfg.Severity = map[string]string{RuleBulletLength: SeverityError}
+
+	issues := Run(req, cfg)
+	if len(issues) != 1 {
+		t.Fatalf("Run returned %d issues, want 1", len(issues))
+	}
+	issue := issues[0]
+	if issue.Severity != SeverityError {
+		t.Errorf("severity = %q, want %q", issue.Severity, SeverityError)
+	}
+	want := &models.LintLocation{Section: 1, SectionType: "projects", Entry: 1, Bullet: 1}
+	if !reflect.DeepEqual(issue.Location, want) {
+		t.Errorf("location = %+v, want %+v", issue.Location, want)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	if errs := ValidateConfig(nil); errs != nil {
+		t.Errorf("ValidateConfig(nil) = %q", errs)
+	}
+
+	errs := ValidateConfig(&models.LintConfig{
+		Disable:        []string{"tense", "spelling"},
+		Severity:       map[string]string{"weak-opener": "fatal"},
+		MaxBulletWords: -1,
+	})
+	for _, want := range []string{
+		`Unknown lint rule "spelling"`,
+		`Lint rule "weak-opener": severity must be "error", "warning" or "info"`,
+		"Lint maxBulletWords must not be negative",
+	} {
+		if !slices.Contains(errs, want) {
+			t.Errorf("ValidateConfig errors %q lack %q", errs, want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/dates"
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// checkMissingSections reports required section types the resume lacks
+func checkMissingSections(r *resume, cfg *models.LintConfig) []finding {
+	required := cfg.RequiredSections
+	if required == nil {
+		required = DefaultRequiredSections
+	}
+
+	var findings []finding
+	for _, sectionType := range required {
+		if slices.Contains(r.sectionTypes, sectionType) {
+			continue
+		}
+		name := strings.ToLower(i18n.Default().Heading(sectionType))
+		if name == "" {
+			name = sectionType
+		}
+		findings = append(findings, finding{message: fmt.Sprintf("Resume has no %s section", name)})
+	}
+	return findings
+}
+
+// weakOpeners are phrases that describe duties rather than achievements
+var weakOpeners = []string{
+	"responsible for", "was responsible for", "duties included", "tasked with",
+	"in charge of", "involved in", "participated in", "worked on", "worked with",
+	"helped", "assisted",
+}
+
+func checkWeakOpeners(r *resume, _ *models.LintConfig) []finding {
+	var findings []finding
+	for _, b := range r.bullets {
+		trimmed := strings.TrimSpace(b.text)
+		text := strings.ToLower(trimmed)
+		for _, opener := range weakOpeners {
+			if text == opener || strings.HasPrefix(text, opener+" ") {
+				findings = append(findings, finding{
+					message:  fmt.Sprintf("Bullet starts with %q; lead with a strong action verb instead", trimmed[:len(opener)]),
+					location: b.location,
+				})
+				break
+			}
+		}
+	}
+	return findings
+}
+
+var (
+	firstPersonRegex = regexp.MustCompile(`(?i)\b(me|my|mine|myself|we|our|ours)\b`)
+	pronounIRegex    = regexp.MustCompile(`\bI\b`)
+)
+
+func checkFirstPerson(r *resume, _ *models.LintConfig) []finding {
+	var findings []finding
+	check := func(text string, loc *models.LintLocation) {
+		pronoun := pronounIRegex.FindString(text)
+		if pronoun == "" {
+			pronoun = firstPersonRegex.FindString(text)
+		}
+		if pronoun != "" {
+			findings = append(findings, finding{
+				message:  fmt.Sprintf("Avoid first-person pronouns such as %q", pronoun),
+				location: loc,
+			})
+		}
+	}
+	for _, p := range r.prose {
+		check(p.text, p.location)
+	}
+	for _, b := range r.bullets {
+		check(b.text, b.location)
+	}
+	return findings
+}
+
+func checkTense(r *resume, _ *models.LintConfig) []finding {
+	var findings []finding
+	for _, b := range r.bullets {
+		if !b.role {
+			continue
+		}
+		verb := firstWord(b.text)
+		switch {
+		case b.current && isPastTense(verb):
+			findings = append(findings, finding{
+				message:  fmt.Sprintf("Current role bullet starts with past tense %q; use present tense", verb),
+				location: b.location,
+			})
+		case !b.current && isPresentTense(verb):
+			findings = append(findings, finding{
+				message:  fmt.Sprintf("Past role bullet starts with present tense %q; use past tense", verb),
+				location: b.location,
+			})
+		}
+	}
+	return findings
+}
+
+// firstWord returns the first word of a bullet without punctuation
+func firstWord(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Trim(fields[0], ".,;:!?\"'()")
+}
+
+func checkDuplicateBullets(r *resume, _ *models.LintConfig) []finding {
+	var findings []finding
+	first := make(map[string]*models.LintLocation)
+	for _, b := range r.bullets {
+		key := strings.TrimRight(strings.Join(strings.Fields(strings.ToLower(b.text)), " "), ".")
+		if key == "" {
+			continue
+		}
+		if loc, ok := first[key]; ok {
+			findings = append(findings, finding{
+				message:  "Bullet duplicates " + describe(loc),
+				location: b.location,
+			})
+			continue
+		}
+		first[key] = b.location
+	}
+	return findings
+}
+
+// metricRegex matches numbers, percentages and amounts that quantify impact
+var metricRegex = regexp.MustCompile(`\d|%|[$€£¥₹]`)
+
+func checkMetrics(r *resume, _ *models.LintConfig) []finding {
+	var findings []finding
+	for _, b := range r.bullets {
+		if b.location.SectionType == "profile_summary" || strings.TrimSpace(b.text) == "" {
+			continue
+		}
+		if !metricRegex.MatchString(b.text) {
+			findings = append(findings, finding{
+				message:  "Bullet has no metric; quantify the impact where possible",
+				location: b.location,
+			})
+		}
+	}
+	return findings
+}
+
+func checkBulletLength(r *resume, cfg *models.LintConfig) []finding {
+	limit := cfg.MaxBulletWords
+	if limit == 0 {
+		limit = DefaultMaxBulletWords
+	}
+
+	var findings []finding
+	for _, b := range r.bullets {
+		if words := len(strings.Fields(b.text)); words > limit {
+			findings = append(findings, finding{
+				message:  fmt.Sprintf("Bullet is %d words long; keep bullets under %d words", words, limit),
+				location: b.location,
+			})
+		}
+	}
+	return findings
+}
+
+// checkDateFormats reports dates written differently from the most common
+// notation. Year-only dates fit alongside any notation.
+func checkDateFormats(r *resume, _ *models.LintConfig) []finding {
+	counts := make(map[string]int)
+	var order []string
+	for _, d := range r.dates {
+		n := dates.Notation(d.value)
+		if n == "" || n == dates.NotationYear {
+			continue
+		}
+		if counts[n] == 0 {
+			order = append(order, n)
+		}
+		counts[n]++
+	}
+	if len(order) < 2 {
+		return nil
+	}
+
+	dominant := order[0]
+	for _, n := range order[1:] {
+		if counts[n] > counts[dominant] {
+			dominant = n
+		}
+	}
+
+	var findings []finding
+	for _, d := range r.dates {
+		n := dates.Notation(d.value)
+		if n == "" || n == dates.NotationYear || n == dominant {
+			continue
+		}
+		findings = append(findings, finding{
+			message:  fmt.Sprintf("Date %q is written like %q while most dates look like %q", d.value, n, dominant),
+			location: d.location,
+		})
+	}
+	return findings
+}
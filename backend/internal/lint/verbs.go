@@ -0,0 +1,63 @@
+package lint
+
+import "strings"
+
+// actionVerbs are common resume verbs in their base form. Tense is only
+// judged for bullets starting with one of these, or their inflections.
+var actionVerbs = strings.Fields(`achieve analyze architect automate build coach collaborate
+communicate conduct configure consolidate contribute convert coordinate create debug define
+deliver deploy design develop document drive enhance establish evaluate execute expand
+facilitate generate grow guide handle help hire identify implement improve increase integrate
+launch lead lower maintain manage mentor migrate modernize monitor negotiate operate optimize
+organize oversee own partner plan present produce prototype publish rebuild recruit redesign
+reduce refactor research resolve review rewrite run scale serve ship simplify spearhead
+streamline support teach test train transform troubleshoot upgrade validate win write`)
+
+// irregularPast maps base forms to irregular or consonant-doubling past forms
+var irregularPast = map[string]string{
+	"build": "built", "drive": "drove", "grow": "grew", "lead": "led", "run": "ran",
+	"write": "wrote", "rewrite": "rewrote", "rebuild": "rebuilt", "oversee": "oversaw",
+	"teach": "taught", "win": "won", "ship": "shipped", "plan": "planned",
+}
+
+var presentForms, pastForms = verbForms()
+
+// verbForms derives the present and past tense forms of the action verbs
+func verbForms() (present, past map[string]bool) {
+	present = make(map[string]bool)
+	past = make(map[string]bool)
+
+	for _, verb := range actionVerbs {
+		present[verb] = true
+		switch {
+		case strings.HasSuffix(verb, "y") && !strings.ContainsRune("aeiou", rune(verb[len(verb)-2])):
+			present[verb[:len(verb)-1]+"ies"] = true
+		case strings.HasSuffix(verb, "s") || strings.HasSuffix(verb, "sh") || strings.HasSuffix(verb, "ch"):
+			present[verb+"es"] = true
+		default:
+			present[verb+"s"] = true
+		}
+
+		switch {
+		case irregularPast[verb] != "":
+			past[irregularPast[verb]] = true
+		case strings.HasSuffix(verb, "e"):
+			past[verb+"d"] = true
+		case strings.HasSuffix(verb, "y") && !strings.ContainsRune("aeiou", rune(verb[len(verb)-2])):
+			past[verb[:len(verb)-1]+"ied"] = true
+		default:
+			past[verb+"ed"] = true
+		}
+	}
+	return present, past
+}
+
+// isPresentTense reports whether a word is a present tense action verb
+func isPresentTense(word string) bool {
+	return presentForms[strings.ToLower(word)]
+}
+
+// isPastTense reports whether a word is a past tense action verb
+func isPastTense(word string) bool {
+	return pastForms[strings.ToLower(word)]
+}
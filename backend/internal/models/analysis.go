@@ -0,0 +1,36 @@
+package models
+
+// AnalyzeRequest is a resume plus the job description to match it against
+type AnalyzeRequest struct {
+	ResumeRequest
+	JobDescription string `json:"jobDescription"`
+}
+
+// AnalysisReport describes how well a resume covers a job description's keywords
+type AnalysisReport struct {
+	Score    int               `json:"score"` // Weighted keyword coverage, 0-100
+	Keywords []KeywordMatch    `json:"keywords"`
+	Missing  []string          `json:"missing"`
+	Sections []SectionCoverage `json:"sections"`
+}
+
+// KeywordMatch is a keyword extracted from the job description
+type KeywordMatch struct {
+	Keyword  string   `json:"keyword"`
+	Skill    bool     `json:"skill"`    // A known skill from the normalization dictionary
+	Count    int      `json:"count"`    // Occurrences in the job description
+	Sections []string `json:"sections"` // Headings of the resume sections mentioning it
+}
+
+// SectionCoverage lists the job description keywords found in one resume section
+type SectionCoverage struct {
+	Type     string   `json:"type"`
+	Title    string   `json:"title"`
+	Keywords []string `json:"keywords"`
+}
+
+// AnalyzeResponse represents a successful analysis response
+type AnalyzeResponse struct {
+	Success bool            `json:"success"`
+	Report  *AnalysisReport `json:"report"`
+}
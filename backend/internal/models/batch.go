@@ -0,0 +1,45 @@
+package models
+
+// BatchRequest represents a batch compilation: either a list of resumes, or
+// one resume compiled once per override
+type BatchRequest struct {
+	Resumes     []ResumeRequest `json:"resumes,omitempty"`
+	Resume      *ResumeRequest  `json:"resume,omitempty"`
+	Overrides   []BatchOverride `json:"overrides,omitempty"`
+	Concurrency int             `json:"concurrency,omitempty"` // Parallel compilations; capped by the server
+}
+
+// BatchOverride replaces rendering options of the shared resume for one
+// item of a batch. Empty fields keep the resume's own value.
+type BatchOverride struct {
+	Locale    string      `json:"locale,omitempty"`
+	DateStyle string      `json:"dateStyle,omitempty"`
+	PDF       *PDFOptions `json:"pdf,omitempty"`
+}
+
+// Batch item statuses
+const (
+	BatchStatusCompiled = "compiled"
+	BatchStatusInvalid  = "invalid"  // Failed validation and was not compiled
+	BatchStatusFailed   = "failed"   // LaTeX compilation failed
+	BatchStatusCanceled = "canceled" // The batch was canceled before the item ran
+)
+
+// BatchItemStatus reports the outcome of one item of a batch
+type BatchItemStatus struct {
+	Index  int      `json:"index"` // Position in resumes or overrides, from 0
+	Name   string   `json:"name"`  // The resume's full name
+	Locale string   `json:"locale,omitempty"`
+	Status string   `json:"status"`
+	File   string   `json:"file,omitempty"` // PDF path in the archive
+	Errors []string `json:"errors,omitempty"`
+}
+
+// BatchManifest lists the outcome of every item of a batch. It is written
+// to the archive as manifest.json.
+type BatchManifest struct {
+	Total    int               `json:"total"`
+	Compiled int               `json:"compiled"`
+	Failed   int               `json:"failed"` // Invalid, failed and canceled items
+	Items    []BatchItemStatus `json:"items"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// CoverLetterRequest represents the incoming cover letter data. The header
+// reuses the resume's basic details so the letter matches the resume.
+type CoverLetterRequest struct {
+	BasicDetails BasicDetails `json:"basicDetails"`
+	Recipient    Recipient    `json:"recipient"`
+	Company      string       `json:"company"`
+	Date         string       `json:"date,omitempty"`       // "YYYY-MM-DD" or free text; defaults to today
+	Salutation   string       `json:"salutation,omitempty"` // Defaults to e.g. "Dear Jane Smith," or "Dear Hiring Manager,"
+	Paragraphs   []string     `json:"paragraphs"`
+	Closing      string       `json:"closing,omitempty"` // Defaults to e.g. "Sincerely,"
+	Locale       string       `json:"locale,omitempty"`
+	PDF          *PDFOptions  `json:"pdf,omitempty"`
+}
+
+// Recipient is the person and address a cover letter is sent to
+type Recipient struct {
+	Name    string   `json:"name,omitempty"`
+	Title   string   `json:"title,omitempty"`   // e.g. "Engineering Manager"
+	Address []string `json:"address,omitempty"` // Printed one line each, below the company
+}
@@ -0,0 +1,17 @@
+package models
+
+// ImportResult is a best-effort resume read from another format, for the
+// user to review and correct before saving
+type ImportResult struct {
+	Resume ResumeRequest `json:"resume"`
+	// Confidence maps the JSON path of each imported field to a score from
+	// 0 to 1, e.g. "sections.2.content.entries.0.startDate": 0.9
+	Confidence map[string]float64 `json:"confidence"`
+	Warnings   []string           `json:"warnings,omitempty"`
+}
+
+// ImportResponse represents a successful import
+type ImportResponse struct {
+	Success bool          `json:"success"`
+	Import  *ImportResult `json:"import"`
+}
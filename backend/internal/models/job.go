@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// JobRequest represents a compilation to run in the background. Type selects
+// which of the payloads is compiled.
+type JobRequest struct {
+	Type        string              `json:"type"` // "resume", "coverLetter" or "batch"
+	Resume      *ResumeRequest      `json:"resume,omitempty"`
+	CoverLetter *CoverLetterRequest `json:"coverLetter,omitempty"`
+	Batch       *BatchRequest       `json:"batch,omitempty"`
+}
+
+// Job types
+const (
+	JobTypeResume      = "resume"
+	JobTypeCoverLetter = "coverLetter"
+	JobTypeBatch       = "batch"
+)
+
+// Job statuses
+const (
+	JobStatusQueued   = "queued"
+	JobStatusRunning  = "running"
+	JobStatusDone     = "done"
+	JobStatusFailed   = "failed"
+	JobStatusCanceled = "canceled"
+)
+
+// Job reports the state of a background compilation
+type Job struct {
+	ID        string     `json:"id"`
+	Type      string     `json:"type"`
+	Status    string     `json:"status"`
+	Stage     string     `json:"stage"`               // e.g. "queued", "generating", "pdflatex pass 1", "done"
+	Completed int        `json:"completed,omitempty"` // Finished items of a batch
+	Total     int        `json:"total,omitempty"`     // Items of a batch
+	Result    *JobResult `json:"result,omitempty"`    // Set when done
+	Error     string     `json:"error,omitempty"`     // Set when failed or canceled
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"` // When a finished job is discarded
+}
+
+// JobResult holds the output of a finished job. The file, a PDF or the
+// batch ZIP archive, is downloaded from DownloadURL until the job expires.
+type JobResult struct {
+	DownloadURL  string              `json:"downloadUrl"`
+	FileName     string              `json:"fileName"`
+	Metrics      *LayoutMetrics      `json:"metrics,omitempty"`
+	Parseability *ParseabilityReport `json:"parseability,omitempty"`
+	Lint         []LintIssue         `json:"lint,omitempty"`
+	Tagged       bool                `json:"tagged,omitempty"`
+	Manifest     *BatchManifest      `json:"manifest,omitempty"` // Batch jobs only
+}
+
+// JobResponse represents a job API response
+type JobResponse struct {
+	Success bool `json:"success"`
+	Job     *Job `json:"job"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// LintConfig customizes the lint rules run over a resume
+type LintConfig struct {
+	Disable          []string          `json:"disable,omitempty"`          // Rule IDs to skip
+	Severity         map[string]string `json:"severity,omitempty"`         // Rule ID -> "error", "warning" or "info"
+	MaxBulletWords   int               `json:"maxBulletWords,omitempty"`   // Defaults to 30
+	RequiredSections []string          `json:"requiredSections,omitempty"` // Defaults to experience, education and tech_skills
+}
+
+// LintIssue is a single ATS or style problem found in a resume
+type LintIssue struct {
+	Rule     string        `json:"rule"`
+	Severity string        `json:"severity"` // "error", "warning" or "info"
+	Message  string        `json:"message"`
+	Location *LintLocation `json:"location,omitempty"` // Unset for resume-wide issues
+}
+
+// LintLocation points at the part of a resume an issue refers to. Indexes
+// are 1-based; zero means the issue is not that specific.
+type LintLocation struct {
+	Section     int    `json:"section"`
+	SectionType string `json:"sectionType"`
+	Entry       int    `json:"entry,omitempty"`
+	Position    int    `json:"position,omitempty"` // Role within a grouped experience entry
+	Bullet      int    `json:"bullet,omitempty"`
+	Field       string `json:"field,omitempty"` // e.g. "startDate"
+}
+
+// LintResponse represents a successful lint response
+type LintResponse struct {
+	Success bool        `json:"success"`
+	Issues  []LintIssue `json:"issues"`
+}
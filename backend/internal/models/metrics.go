@@ -0,0 +1,29 @@
+package models
+
+// LayoutMetrics describes the rendered PDF, extracted from the pdflatex log
+type LayoutMetrics struct {
+	PageCount      int             `json:"pageCount"`
+	LastPageFill   float64         `json:"lastPageFill"`            // Percentage of the last page's text area in use
+	OverflowLines  int             `json:"overflowLines,omitempty"` // Lines spilling onto the last page of a multi-page resume
+	OverfullBoxes  int             `json:"overfullBoxes"`
+	UnderfullBoxes int             `json:"underfullBoxes"`
+	Warnings       []BoxWarning    `json:"warnings,omitempty"`
+	Sections       []SectionExtent `json:"sections,omitempty"`
+}
+
+// BoxWarning represents an overfull or underfull box reported by pdflatex
+type BoxWarning struct {
+	Kind     string `json:"kind"`   // "overfull" or "underfull"
+	Box      string `json:"box"`    // "hbox" or "vbox"
+	Detail   string `json:"detail"` // e.g. "12.5pt too wide" or "badness 10000"
+	Location string `json:"location,omitempty"`
+}
+
+// SectionExtent represents the vertical space a section occupies in the PDF
+type SectionExtent struct {
+	Type      string  `json:"type"`
+	StartPage int     `json:"startPage"`
+	EndPage   int     `json:"endPage"`
+	Height    float64 `json:"height"` // In points
+	Lines     float64 `json:"lines"`  // Height expressed in baseline skips
+}
@@ -0,0 +1,16 @@
+package models
+
+// ParseabilityReport describes how well the text of a compiled PDF survives
+// extraction by a text-based ATS parser
+type ParseabilityReport struct {
+	Score  int          `json:"score"` // Weighted share of passed checks, 0-100
+	Checks []ParseCheck `json:"checks"`
+}
+
+// ParseCheck is the outcome of one parseability check
+type ParseCheck struct {
+	ID      string   `json:"id"`
+	Passed  bool     `json:"passed"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"` // e.g. the headings or bullets that were not found
+}
@@ -0,0 +1,11 @@
+package models
+
+// PDFOptions controls the document metadata and the PDF standards the
+// compiled resume conforms to
+type PDFOptions struct {
+	Title    string   `json:"title,omitempty"`    // Defaults to "<name> Resume", localized
+	Subject  string   `json:"subject,omitempty"`  // Defaults to the most recent job title
+	Keywords []string `json:"keywords,omitempty"` // Added after the skills listed in the resume
+	PDFA     bool     `json:"pdfa,omitempty"`     // PDF/A-2b output for archiving
+	Tagged   bool     `json:"tagged,omitempty"`   // Tagged PDF, when the TeX installation supports it
+}
@@ -1,9 +1,15 @@
 package models
 
+import "encoding/json"
+
 // ResumeRequest represents the incoming resume data
 type ResumeRequest struct {
 	BasicDetails BasicDetails `json:"basicDetails"`
 	Sections     []Section    `json:"sections"`
+	Locale       string       `json:"locale,omitempty"`    // e.g. "en", "fr", "fr-CA", "de", "es", "hi"
+	DateStyle    string       `json:"dateStyle,omitempty"` // "short" (default), "long", "numeric" or "year"
+	Lint         *LintConfig  `json:"lint,omitempty"`      // Runs the lint rules alongside compilation when set
+	PDF          *PDFOptions  `json:"pdf,omitempty"`       // Metadata overrides and PDF/A or tagged output
 }
 
 // BasicDetails contains personal information
@@ -11,35 +17,70 @@ type BasicDetails struct {
 	FirstName string `json:"firstName"`
 	LastName  string `json:"lastName"`
 	Email     string `json:"email"`
+	Phone     string `json:"phone,omitempty"` // E.164, e.g. "+14165551234"
 	City      string `json:"city"`
 	Province  string `json:"province"`
 	GitHub    string `json:"github,omitempty"`
 	LinkedIn  string `json:"linkedin,omitempty"`
 	Portfolio string `json:"portfolio,omitempty"`
+
+	Links      []ContactLink `json:"links,omitempty"`
+	LinkOrder  []string      `json:"linkOrder,omitempty"`  // Kinds rendered first, in this order
+	LinkLayout string        `json:"linkLayout,omitempty"` // "inline" (default) or "stacked"
+}
+
+// ContactLink represents an additional profile or web link
+type ContactLink struct {
+	Kind  string `json:"kind,omitempty"` // e.g. "stackoverflow", "scholar", "orcid", "mastodon", "personal"
+	Label string `json:"label,omitempty"`
+	URL   string `json:"url"`
 }
 
 // Section represents a resume section (profile, experience, etc.)
 type Section struct {
-	Type    string      `json:"type"`
-	Content interface{} `json:"content"`
+	ID         string      `json:"id,omitempty"` // Stable ID, assigned when the resume is saved
+	Type       string      `json:"type"`
+	Title      string      `json:"title,omitempty"`      // Overrides the default heading
+	Sort       string      `json:"sort,omitempty"`       // "none" (default) or "reverse_chronological"
+	ShowTenure bool        `json:"showTenure,omitempty"` // Show e.g. "(2 yrs 4 mos)" after date ranges
+	Content    interface{} `json:"content"`
 }
 
 // ProfileSummaryContent represents profile summary section data
 type ProfileSummaryContent struct {
-	Format  string   `json:"format"` // "paragraph" or "bullets"
-	Text    string   `json:"text,omitempty"`
-	Bullets []string `json:"bullets,omitempty"`
+	Format    string   `json:"format"` // "paragraph" or "bullets"
+	Text      string   `json:"text,omitempty"`
+	Bullets   []string `json:"bullets,omitempty"`
+	BulletIDs []string `json:"bulletIds,omitempty"` // Parallel to Bullets
 }
 
-// TechSkillsContent represents technical skills section data
+// Skills section layouts
+const (
+	SkillsLayoutTable  = "table"  // One row per category (default)
+	SkillsLayoutList   = "list"   // A single comma-separated line
+	SkillsLayoutLevels = "levels" // One row per proficiency level
+)
+
+// TechSkillsContent represents technical skills section data. Skill names
+// are rendered as written unless NormalizeSpelling opts in to the canonical
+// spelling of known skills.
 type TechSkillsContent struct {
-	Categories []SkillCategory `json:"categories"`
+	Categories        []SkillCategory `json:"categories"`
+	Layout            string          `json:"layout,omitempty"` // "table", "list" or "levels"
+	NormalizeSpelling bool            `json:"normalizeSpelling,omitempty"`
 }
 
 // SkillCategory represents a category of skills
 type SkillCategory struct {
-	Name   string `json:"name"`
-	Skills string `json:"skills"`
+	Name   string      `json:"name"`
+	Skills string      `json:"skills"` // Comma-separated, e.g. "Go (Expert), k8s"
+	Items  []SkillItem `json:"items,omitempty"`
+}
+
+// SkillItem represents a single skill with an optional proficiency level
+type SkillItem struct {
+	Name  string `json:"name"`
+	Level string `json:"level,omitempty"` // "expert", "advanced", "intermediate" or "beginner"
 }
 
 // ExperienceContent represents experience section data
@@ -47,28 +88,63 @@ type ExperienceContent struct {
 	Entries []ExperienceEntry `json:"entries"`
 }
 
-// ExperienceEntry represents a single work experience
+// ExperienceEntry represents a single work experience. When Positions is
+// set, the entry is rendered as a company header with one block per role
+// and the flat Title/date/Bullets fields are ignored.
 type ExperienceEntry struct {
-	Company   string   `json:"company"`
+	ID        string               `json:"id,omitempty"`
+	Company   string               `json:"company"`
+	Title     string               `json:"title"`
+	Location  string               `json:"location"`
+	StartDate string               `json:"startDate"`
+	EndDate   string               `json:"endDate"`
+	Current   bool                 `json:"current,omitempty"` // Renders the end date as "Present"
+	Bullets   []string             `json:"bullets"`
+	BulletIDs []string             `json:"bulletIds,omitempty"` // Parallel to Bullets
+	Positions []ExperiencePosition `json:"positions,omitempty"`
+}
+
+// ExperiencePosition represents one role held at a company
+type ExperiencePosition struct {
+	ID        string   `json:"id,omitempty"`
 	Title     string   `json:"title"`
-	Location  string   `json:"location"`
 	StartDate string   `json:"startDate"`
 	EndDate   string   `json:"endDate"`
+	Current   bool     `json:"current,omitempty"`
 	Bullets   []string `json:"bullets"`
+	BulletIDs []string `json:"bulletIds,omitempty"` // Parallel to Bullets
 }
 
+// Project technology display styles
+const (
+	TechStyleSuffix = "suffix" // Italic suffix on the header line (default)
+	TechStyleLine   = "line"   // Separate "Technologies:" line
+	TechStyleHidden = "hidden"
+)
+
 // ProjectsContent represents projects section data
 type ProjectsContent struct {
-	Entries []ProjectEntry `json:"entries"`
+	Entries   []ProjectEntry `json:"entries"`
+	TechStyle string         `json:"techStyle,omitempty"` // "suffix", "line" or "hidden"
 }
 
 // ProjectEntry represents a single project
 type ProjectEntry struct {
-	Name         string   `json:"name"`
-	Description  []string `json:"description"`
-	Technologies string   `json:"technologies,omitempty"`
-	Link         string   `json:"link,omitempty"`
-	Date         string   `json:"date,omitempty"`
+	ID           string        `json:"id,omitempty"`
+	Name         string        `json:"name"`
+	Description  []string      `json:"description"`
+	BulletIDs    []string      `json:"bulletIds,omitempty"`    // Parallel to Description
+	Technologies string        `json:"technologies,omitempty"` // Comma-separated
+	Link         string        `json:"link,omitempty"`         // Deprecated: use Links
+	Links        []ProjectLink `json:"links,omitempty"`
+	Date         string        `json:"date,omitempty"`
+}
+
+// ProjectLink represents a labeled project link
+type ProjectLink struct {
+	Kind  string `json:"kind,omitempty"` // "repo", "demo", "paper", "video", "docs" or "other"
+	Label string `json:"label,omitempty"`
+	URL   string `json:"url"`
 }
 
 // VolunteerContent represents volunteer section data
@@ -78,12 +154,15 @@ type VolunteerContent struct {
 
 // VolunteerEntry represents a single volunteer role
 type VolunteerEntry struct {
+	ID           string   `json:"id,omitempty"`
 	Organization string   `json:"organization"`
 	Title        string   `json:"title"`
 	Location     string   `json:"location,omitempty"`
 	StartDate    string   `json:"startDate"`
 	EndDate      string   `json:"endDate"`
+	Current      bool     `json:"current,omitempty"`
 	Bullets      []string `json:"bullets"`
+	BulletIDs    []string `json:"bulletIds,omitempty"` // Parallel to Bullets
 }
 
 // EducationContent represents education section data
@@ -93,18 +172,166 @@ type EducationContent struct {
 
 // EducationEntry represents a single education entry
 type EducationEntry struct {
-	Institution string `json:"institution"`
-	Degree      string `json:"degree"`
-	StartDate   string `json:"startDate"`
-	EndDate     string `json:"endDate"`
+	ID          string        `json:"id,omitempty"`
+	Institution string        `json:"institution"`
+	Degree      string        `json:"degree"`
+	Location    string        `json:"location,omitempty"`
+	StartDate   string        `json:"startDate"`
+	EndDate     string        `json:"endDate"`
+	Current     bool          `json:"current,omitempty"`
+	GPA         NumericString `json:"gpa,omitempty"`
+	GPAScale    NumericString `json:"gpaScale,omitempty"` // e.g. "4.0"
+	ShowGPA     *bool         `json:"showGpa,omitempty"`  // Defaults to showing the GPA when set
+	Honors      []string      `json:"honors,omitempty"`
+	Minors      []string      `json:"minors,omitempty"`
+	Coursework  []string      `json:"coursework,omitempty"`
+	Thesis      string        `json:"thesis,omitempty"`
+}
+
+// NumericString is a string field that clients may also send as a JSON
+// number, such as a GPA of 3.8
+type NumericString string
+
+// UnmarshalJSON accepts a JSON string or number
+func (s *NumericString) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		*s = NumericString(str)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*s = NumericString(n)
+	return nil
+}
+
+// CertificationsContent represents certifications section data
+type CertificationsContent struct {
+	Entries []CertificationEntry `json:"entries"`
+}
+
+// CertificationEntry represents a single certification
+type CertificationEntry struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Issuer       string `json:"issuer,omitempty"`
+	Date         string `json:"date,omitempty"`
+	ExpiryDate   string `json:"expiryDate,omitempty"`
+	CredentialID string `json:"credentialId,omitempty"`
+	URL          string `json:"url,omitempty"` // Verification link
+}
+
+// AwardsContent represents awards section data
+type AwardsContent struct {
+	Entries []AwardEntry `json:"entries"`
+}
+
+// AwardEntry represents a single award or honor
+type AwardEntry struct {
+	ID          string `json:"id,omitempty"`
+	Title       string `json:"title"`
+	Issuer      string `json:"issuer,omitempty"`
+	Date        string `json:"date,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// PublicationsContent represents publications section data
+type PublicationsContent struct {
+	Entries []PublicationEntry `json:"entries"`
+}
+
+// PublicationEntry represents a single paper or article
+type PublicationEntry struct {
+	ID      string   `json:"id,omitempty"`
+	Title   string   `json:"title"`
+	Authors []string `json:"authors,omitempty"`
+	Venue   string   `json:"venue,omitempty"`
+	Year    string   `json:"year,omitempty"`
+	DOI     string   `json:"doi,omitempty"` // e.g. "10.1145/3368089.3409741"
+	URL     string   `json:"url,omitempty"`
+}
+
+// LanguagesContent represents spoken languages section data
+type LanguagesContent struct {
+	Entries []LanguageEntry `json:"entries"`
+}
+
+// LanguageEntry represents a spoken language
+type LanguageEntry struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Proficiency string `json:"proficiency,omitempty"` // "native", "fluent", "professional", "intermediate", "basic" or a CEFR level
+}
+
+// InterestsContent represents interests section data
+type InterestsContent struct {
+	Items []string `json:"items"`
+}
+
+// Custom section layouts
+const (
+	CustomLayoutBullets   = "bullets"
+	CustomLayoutTable     = "table"
+	CustomLayoutEntries   = "entries"
+	CustomLayoutParagraph = "paragraph"
+)
+
+// CustomContent represents a user-defined section such as "Patents" or
+// "Speaking". Only the field matching Layout is rendered; the section's
+// Title is used as its heading.
+type CustomContent struct {
+	Layout    string        `json:"layout"` // "bullets", "table", "entries" or "paragraph"
+	Text      string        `json:"text,omitempty"`
+	Bullets   []string      `json:"bullets,omitempty"`
+	BulletIDs []string      `json:"bulletIds,omitempty"` // Parallel to Bullets
+	Rows      []CustomRow   `json:"rows,omitempty"`
+	Entries   []CustomEntry `json:"entries,omitempty"`
+}
+
+// CustomRow represents a key/value row of a table layout
+type CustomRow struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// CustomEntry represents an entry with a header, dates and bullets
+type CustomEntry struct {
+	ID        string   `json:"id,omitempty"`
+	Title     string   `json:"title"`
+	Subtitle  string   `json:"subtitle,omitempty"`
+	Location  string   `json:"location,omitempty"`
+	StartDate string   `json:"startDate,omitempty"`
+	EndDate   string   `json:"endDate,omitempty"`
+	Current   bool     `json:"current,omitempty"`
+	Bullets   []string `json:"bullets,omitempty"`
+	BulletIDs []string `json:"bulletIds,omitempty"` // Parallel to Bullets
+}
+
+// DecodeContent converts a section's generic JSON content into one of the
+// typed content structs above
+func DecodeContent(content interface{}, v interface{}) error {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
 }
 
 // SuccessResponse represents a successful API response
 type SuccessResponse struct {
-	Success   bool   `json:"success"`
-	Message   string `json:"message"`
-	PDFUrl    string `json:"pdfUrl"`
-	PDFBase64 string `json:"pdfBase64,omitempty"`
+	Success      bool                `json:"success"`
+	Message      string              `json:"message"`
+	PDFUrl       string              `json:"pdfUrl"`
+	PDFBase64    string              `json:"pdfBase64,omitempty"`
+	Metrics      *LayoutMetrics      `json:"metrics,omitempty"`
+	Parseability *ParseabilityReport `json:"parseability,omitempty"`
+	Lint         []LintIssue         `json:"lint,omitempty"`
+	Tagged       bool                `json:"tagged,omitempty"` // The PDF carries a structure tree
 }
 
 // ErrorResponse represents an error API response
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Revision is an immutable version of a saved resume, recorded on every save
+type Revision struct {
+	Version   int            `json:"version"`
+	Note      string         `json:"note,omitempty"` // e.g. "Tailored for Acme"
+	CreatedAt time.Time      `json:"createdAt"`
+	Resume    *ResumeRequest `json:"resume,omitempty"` // Omitted from revision listings
+}
+
+// RevisionsResponse lists the revisions of a saved resume, newest first
+type RevisionsResponse struct {
+	Success   bool       `json:"success"`
+	Revisions []Revision `json:"revisions"`
+}
+
+// RevisionResponse represents a single revision of a saved resume
+type RevisionResponse struct {
+	Success  bool      `json:"success"`
+	Revision *Revision `json:"revision"`
+}
+
+// Change is one structural difference between two revisions
+type Change struct {
+	Kind    string `json:"kind"`              // "added", "removed", "changed" or "moved"
+	Target  string `json:"target"`            // e.g. "section", "entry", "bullet" or "field"
+	Section string `json:"section,omitempty"` // Section heading, e.g. "Experience"
+	Entry   string `json:"entry,omitempty"`   // Entry label, e.g. "Acme Corp – Engineer"
+	Field   string `json:"field,omitempty"`   // e.g. "endDate" or "basicDetails.email"
+	Index   int    `json:"index,omitempty"`   // 1-based list position, in the old revision for removals
+	Before  string `json:"before,omitempty"`
+	After   string `json:"after,omitempty"`
+	Summary string `json:"summary"`
+}
+
+// DiffResponse lists the changes from one revision to another
+type DiffResponse struct {
+	Success bool     `json:"success"`
+	From    int      `json:"from"`
+	To      int      `json:"to"`
+	Changes []Change `json:"changes"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// SavedResume is a resume document stored on the server
+type SavedResume struct {
+	ID        string        `json:"id"`
+	Version   int           `json:"version"` // Also sent as the ETag, e.g. "v3"
+	Resume    ResumeRequest `json:"resume"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+// SavedResumeResponse represents a successful save or load of a stored resume
+type SavedResumeResponse struct {
+	Success   bool         `json:"success"`
+	Resume    *SavedResume `json:"resume"`
+	EditToken string       `json:"editToken,omitempty"` // Only returned when the resume is created
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Variant tailors a saved master resume for one application. It stores only
+// the selection, so later edits to the master flow into the variant. IDs
+// refer to the stable IDs of the master's sections, entries and bullets.
+type Variant struct {
+	Name         string                 `json:"name"`                   // e.g. "Acme – Backend Engineer"
+	Include      []string               `json:"include,omitempty"`      // When set, keeps only these items, their ancestors and their contents
+	Exclude      []string               `json:"exclude,omitempty"`      // Items dropped along with their contents
+	SectionOrder []string               `json:"sectionOrder,omitempty"` // Section IDs rendered first, in this order
+	Summary      *ProfileSummaryContent `json:"summary,omitempty"`      // Replaces the profile summary
+	PDF          *PDFOptions            `json:"pdf,omitempty"`          // Replaces the master's PDF options
+}
+
+// SavedVariant is a variant stored with its master resume
+type SavedVariant struct {
+	ID        string         `json:"id"`
+	MasterID  string         `json:"masterId"`
+	Version   int            `json:"version"` // Also sent as the ETag, e.g. "v3"
+	Variant   Variant        `json:"variant"`
+	Resume    *ResumeRequest `json:"resume,omitempty"` // The variant applied to the current master
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// VariantResponse represents a successful save or load of a variant
+type VariantResponse struct {
+	Success bool          `json:"success"`
+	Variant *SavedVariant `json:"variant"`
+}
+
+// VariantsResponse lists the variants of a master resume
+type VariantsResponse struct {
+	Success  bool           `json:"success"`
+	Variants []SavedVariant `json:"variants"`
+}
@@ -0,0 +1,419 @@
+package pdftext
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/dates"
+	"github.com/sahil/ats-resume-maker/backend/internal/i18n"
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// Check IDs
+const (
+	CheckTextLayer    = "text-layer"
+	CheckToUnicode    = "tounicode"
+	CheckGlyphs       = "glyphs"
+	CheckEmail        = "email"
+	CheckHeadings     = "headings"
+	CheckReadingOrder = "reading-order"
+	CheckDates        = "dates"
+	CheckBullets      = "bullets"
+	CheckColumns      = "columns"
+)
+
+// weights sum to 100; a PDF without a text layer scores zero
+var weights = map[string]float64{
+	CheckToUnicode:    20,
+	CheckGlyphs:       20,
+	CheckEmail:        10,
+	CheckHeadings:     15,
+	CheckReadingOrder: 10,
+	CheckDates:        10,
+	CheckBullets:      10,
+	CheckColumns:      5,
+}
+
+const (
+	minTextLength  = 50  // Characters below which a PDF has no usable text layer
+	bulletWords    = 8   // Leading bullet words that must survive extraction
+	maxDetails     = 5   // Failures listed per check
+	columnFraction = 0.3 // Share of the page width each side of a column gap spans
+	minColumnLines = 3   // Side-by-side lines that indicate a multi-column layout
+)
+
+// Check extracts the text of a compiled resume and verifies that the parts
+// an ATS relies on come through intact
+func Check(pdfData []byte, req *models.ResumeRequest) *models.ParseabilityReport {
+	report := &models.ParseabilityReport{}
+
+	doc, err := Extract(pdfData)
+	if err != nil {
+		report.Checks = append(report.Checks, models.ParseCheck{ID: CheckTextLayer, Message: "Text could not be extracted: " + err.Error()})
+		return report
+	}
+	text := doc.Text()
+	if len(strings.TrimSpace(text)) < minTextLength {
+		report.Checks = append(report.Checks, models.ParseCheck{ID: CheckTextLayer, Message: "The PDF has no extractable text layer"})
+		return report
+	}
+	report.Checks = append(report.Checks, models.ParseCheck{ID: CheckTextLayer, Passed: true, Message: fmt.Sprintf("%d characters extracted", len([]rune(text)))})
+
+	locale, ok := i18n.Lookup(req.Locale)
+	if !ok {
+		locale = i18n.Default()
+	}
+	headings := renderedHeadings(req.Sections, locale)
+	normalized := normalize(joinHyphenated(text))
+
+	var score float64
+	for _, c := range []struct {
+		id  string
+		run func() (models.ParseCheck, float64)
+	}{
+		{CheckToUnicode, func() (models.ParseCheck, float64) { return checkToUnicode(doc) }},
+		{CheckGlyphs, func() (models.ParseCheck, float64) { return checkGlyphs(text) }},
+		{CheckEmail, func() (models.ParseCheck, float64) { return checkEmail(text, req.BasicDetails.Email) }},
+		{CheckHeadings, func() (models.ParseCheck, float64) { return checkHeadings(doc, headings) }},
+		{CheckReadingOrder, func() (models.ParseCheck, float64) { return checkReadingOrder(doc, headings) }},
+		{CheckDates, func() (models.ParseCheck, float64) { return checkDates(normalized, req.Sections) }},
+		{CheckBullets, func() (models.ParseCheck, float64) { return checkBullets(normalized, req.Sections) }},
+		{CheckColumns, func() (models.ParseCheck, float64) { return checkColumns(doc) }},
+	} {
+		check, fraction := c.run()
+		check.ID = c.id
+		report.Checks = append(report.Checks, check)
+		score += weights[c.id] * fraction
+	}
+	report.Score = int(math.Round(score))
+
+	return report
+}
+
+// result builds a check that passes when every item was found
+func result(found, total int, missing []string, what string) (models.ParseCheck, float64) {
+	if total == 0 {
+		return models.ParseCheck{Passed: true, Message: "No " + what + " to check"}, 1
+	}
+	check := models.ParseCheck{
+		Passed:  found == total,
+		Message: fmt.Sprintf("%d of %d %s found in the extracted text", found, total, what),
+	}
+	if len(missing) > maxDetails {
+		missing = missing[:maxDetails]
+	}
+	check.Details = missing
+	return check, float64(found) / float64(total)
+}
+
+// checkToUnicode requires a ToUnicode map on every font, without which
+// parsers have to guess what each glyph means
+func checkToUnicode(doc *Document) (models.ParseCheck, float64) {
+	var missing []string
+	for _, f := range doc.Fonts {
+		if !f.ToUnicode {
+			missing = append(missing, f.Name+" ("+f.Subtype+")")
+		}
+	}
+	if len(missing) > 0 {
+		return models.ParseCheck{
+			Message: fmt.Sprintf("%d of %d fonts lack a ToUnicode map", len(missing), len(doc.Fonts)),
+			Details: missing,
+		}, 1 - float64(len(missing))/float64(len(doc.Fonts))
+	}
+	return models.ParseCheck{Passed: true, Message: "All fonts map glyphs to Unicode"}, 1
+}
+
+// checkGlyphs looks for characters that garble keywords: ligatures such as
+// "ﬁ", replacement characters, control codes and private-use glyphs
+func checkGlyphs(text string) (models.ParseCheck, float64) {
+	bad := 0
+	var samples []string
+	seen := make(map[rune]bool)
+	for _, r := range text {
+		if !garbled(r) {
+			continue
+		}
+		bad++
+		if !seen[r] && len(samples) < maxDetails {
+			seen[r] = true
+			samples = append(samples, strconv.QuoteRune(r))
+		}
+	}
+	if bad > 0 {
+		return models.ParseCheck{
+			Message: fmt.Sprintf("%d characters extract as ligatures or unreadable glyphs", bad),
+			Details: samples,
+		}, 0
+	}
+	return models.ParseCheck{Passed: true, Message: "No ligatures or unreadable glyphs"}, 1
+}
+
+func garbled(r rune) bool {
+	switch {
+	case r >= 0xFB00 && r <= 0xFB06: // Latin ligatures
+		return true
+	case r == unicode.ReplacementChar:
+		return true
+	case r < 0x20 && r != '\n' && r != '\t':
+		return true
+	}
+	return unicode.Is(unicode.Co, r)
+}
+
+func checkEmail(text, email string) (models.ParseCheck, float64) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return models.ParseCheck{Passed: true, Message: "No email to check"}, 1
+	}
+	if strings.Contains(strings.ToLower(text), email) {
+		return models.ParseCheck{Passed: true, Message: "Email address extracted intact"}, 1
+	}
+	return models.ParseCheck{Message: "Email address " + email + " was not found in the extracted text"}, 0
+}
+
+// renderedHeadings returns the headings of the sections that render content
+func renderedHeadings(sections []models.Section, locale *i18n.Locale) []string {
+	var headings []string
+	for _, section := range sections {
+		if !hasText(section.Content) {
+			continue
+		}
+		heading := section.Title
+		if heading == "" {
+			heading = locale.Heading(section.Type)
+		}
+		if heading != "" {
+			headings = append(headings, heading)
+		}
+	}
+	return headings
+}
+
+// headingLines returns the index of the line holding each heading, or -1
+func headingLines(doc *Document, headings []string) []int {
+	var lines []string
+	for _, page := range doc.Pages {
+		for _, line := range page.Lines {
+			lines = append(lines, normalize(line.Text()))
+		}
+	}
+
+	indexes := make([]int, len(headings))
+	for i, heading := range headings {
+		indexes[i] = -1
+		want := normalize(heading)
+		for j, line := range lines {
+			if line == want {
+				indexes[i] = j
+				break
+			}
+		}
+	}
+	return indexes
+}
+
+func checkHeadings(doc *Document, headings []string) (models.ParseCheck, float64) {
+	var missing []string
+	for i, line := range headingLines(doc, headings) {
+		if line < 0 {
+			missing = append(missing, headings[i])
+		}
+	}
+	return result(len(headings)-len(missing), len(headings), missing, "section headings")
+}
+
+// checkReadingOrder verifies that headings are read in the order the
+// sections were given
+func checkReadingOrder(doc *Document, headings []string) (models.ParseCheck, float64) {
+	prev := -1
+	var out []string
+	for i, line := range headingLines(doc, headings) {
+		if line < 0 {
+			continue
+		}
+		if line < prev {
+			out = append(out, headings[i])
+		}
+		prev = max(prev, line)
+	}
+	if len(out) > 0 {
+		return models.ParseCheck{Message: "Sections are extracted out of order", Details: out}, 0
+	}
+	return models.ParseCheck{Passed: true, Message: "Sections are extracted in order"}, 1
+}
+
+// checkDates verifies that the year of every entry date survives extraction
+func checkDates(normalized string, sections []models.Section) (models.ParseCheck, float64) {
+	years := make(map[string]bool)
+	var order []string
+	for _, section := range sections {
+		for _, raw := range stringsUnder(section.Content, "startDate", "endDate", "date", "year") {
+			d, err := dates.Parse(raw)
+			if err != nil || d.Year == 0 {
+				continue
+			}
+			year := strconv.Itoa(d.Year)
+			if !years[year] {
+				years[year] = true
+				order = append(order, year)
+			}
+		}
+	}
+
+	var missing []string
+	for _, year := range order {
+		if !strings.Contains(" "+normalized+" ", " "+year+" ") {
+			missing = append(missing, year)
+		}
+	}
+	return result(len(order)-len(missing), len(order), missing, "entry years")
+}
+
+// checkBullets verifies that the opening words of every bullet survive
+// extraction in order
+func checkBullets(normalized string, sections []models.Section) (models.ParseCheck, float64) {
+	var missing []string
+	total := 0
+	for _, section := range sections {
+		for _, bullet := range stringsUnder(section.Content, "bullets", "description") {
+			words := strings.Fields(normalize(bullet))
+			if len(words) == 0 {
+				continue
+			}
+			total++
+			prefix := strings.Join(words[:min(len(words), bulletWords)], " ")
+			if !strings.Contains(normalized, prefix) {
+				missing = append(missing, bullet)
+			}
+		}
+	}
+	return result(total-len(missing), total, missing, "bullets")
+}
+
+// checkColumns flags side-by-side text blocks, which parsers read across
+// and interleave. A date pushed to the right of a heading is not a column.
+func checkColumns(doc *Document) (models.ParseCheck, float64) {
+	columnar := 0
+	for _, page := range doc.Pages {
+		if page.Width <= 0 {
+			continue
+		}
+		for _, line := range page.Lines {
+			for i := 1; i < len(line.Segments); i++ {
+				left, right := line.Segments[i-1], line.Segments[i]
+				if left.X1-left.X0 > columnFraction*page.Width && right.X1-right.X0 > columnFraction*page.Width {
+					columnar++
+					break
+				}
+			}
+		}
+	}
+	if columnar >= minColumnLines {
+		return models.ParseCheck{Message: fmt.Sprintf("%d lines hold side-by-side columns that parsers merge", columnar)}, 0
+	}
+	return models.ParseCheck{Passed: true, Message: "Single-column layout"}, 1
+}
+
+// joinHyphenated rejoins words hyphenated across a line break
+func joinHyphenated(text string) string {
+	var sb strings.Builder
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if i+1 < len(lines) && strings.HasSuffix(line, "-") && startsLower(lines[i+1]) {
+			sb.WriteString(strings.TrimSuffix(line, "-"))
+			continue
+		}
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func startsLower(s string) bool {
+	for _, r := range s {
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// normalize lowercases text, strips accents and reduces everything but
+// letters and digits to single spaces, so LaTeX quotes, dashes and
+// composed accents compare equal to the submitted text
+func normalize(s string) string {
+	var sb strings.Builder
+	space := true
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+			space = false
+		case !space:
+			sb.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// hasText reports whether section content holds any non-empty string
+func hasText(content interface{}) bool {
+	switch v := content.(type) {
+	case string:
+		return strings.TrimSpace(v) != ""
+	case []interface{}:
+		for _, item := range v {
+			if hasText(item) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			// Stable IDs are not rendered
+			if key != "id" && key != "bulletIds" && hasText(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringsUnder collects the strings stored under the given keys anywhere in
+// section content, either directly or as string lists
+func stringsUnder(content interface{}, keys ...string) []string {
+	var found []string
+	switch v := content.(type) {
+	case []interface{}:
+		for _, item := range v {
+			found = append(found, stringsUnder(item, keys...)...)
+		}
+	case map[string]interface{}:
+		// Sorted keys keep the reported order stable
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			item := v[key]
+			if !slices.Contains(keys, key) {
+				found = append(found, stringsUnder(item, keys...)...)
+				continue
+			}
+			switch value := item.(type) {
+			case string:
+				found = append(found, value)
+			case []interface{}:
+				for _, s := range value {
+					if str, ok := s.(string); ok {
+						found = append(found, str)
+					}
+				}
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,385 @@
+package pdftext
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Document is the text extracted from a PDF
+type Document struct {
+	Pages []Page
+	Fonts []Font // Fonts used for text, one entry per base font
+}
+
+// Page holds the text lines of one page, top to bottom, and the web links
+// placed on it
+type Page struct {
+	Width float64
+	Lines []Line
+	Links []Link
+}
+
+// Line is the text sharing a baseline, split into segments where a wide
+// horizontal gap separates them
+type Line struct {
+	Y        float64
+	Size     float64 // Largest font size on the line
+	Segments []Segment
+}
+
+// Segment is a run of text on a line
+type Segment struct {
+	X0, X1 float64
+	Text   string
+}
+
+// Link is a link annotation pointing at a URI. Y is the baseline of the
+// text it covers and Text that text, which is empty when the link covers
+// none.
+type Link struct {
+	X0, X1, Y float64
+	URI       string
+	Text      string
+}
+
+// Text returns the line's segments joined by spaces
+func (l Line) Text() string {
+	parts := make([]string, len(l.Segments))
+	for i, s := range l.Segments {
+		parts[i] = s.Text
+	}
+	return strings.Join(parts, " ")
+}
+
+// Font describes a font that text was drawn with
+type Font struct {
+	Name      string // Base font name without the subset prefix
+	Subtype   string // e.g. "Type1", "Type0", "Type3"
+	ToUnicode bool   // Carries a ToUnicode map from glyphs to text
+}
+
+// Text returns the whole document as lines of text, pages in order
+func (d *Document) Text() string {
+	var sb strings.Builder
+	for _, page := range d.Pages {
+		for _, line := range page.Lines {
+			sb.WriteString(line.Text())
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// glyph is a decoded character placed on the page
+type glyph struct {
+	x, end, y, size float64
+	text            string
+}
+
+const (
+	spaceGap   = 0.2 // Gap between glyphs, in ems, read as a word space
+	segmentGap = 2.0 // Gap between glyphs, in ems, that starts a new segment
+	lineJitter = 2.0 // Baseline difference, in points, still read as one line
+)
+
+// Extract reads the text of a PDF the way a text-based ATS parser does:
+// glyphs are decoded through the fonts' ToUnicode maps or encodings and
+// ordered by position, with spaces inferred from the gaps between them.
+func Extract(data []byte) (doc *Document, err error) {
+	// The PDF reader panics on malformed input
+	defer func() {
+		if r := recover(); r != nil {
+			doc, err = nil, fmt.Errorf("malformed PDF: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	doc = &Document{}
+	seen := make(map[string]bool)
+	for i := 1; i <= reader.NumPage(); i++ {
+		p := reader.Page(i)
+		if p.V.IsNull() {
+			continue
+		}
+
+		fonts := make(map[string]*font)
+		for _, name := range p.Fonts() {
+			f := loadFont(p.Font(name).V)
+			fonts[name] = f
+			if !seen[f.info.Name] {
+				seen[f.info.Name] = true
+				doc.Fonts = append(doc.Fonts, f.info)
+			}
+		}
+
+		box := inherited(p.V, "MediaBox")
+		page := Page{Width: box.Index(2).Float64() - box.Index(0).Float64()}
+		glyphs := readGlyphs(p.V.Key("Contents"), fonts)
+		page.Links = readLinks(p.V.Key("Annots"), glyphs)
+		page.Lines = layoutLines(glyphs)
+		doc.Pages = append(doc.Pages, page)
+	}
+	return doc, nil
+}
+
+// inherited looks up a page attribute that may be set on an ancestor node
+func inherited(v pdf.Value, key string) pdf.Value {
+	for ; !v.IsNull(); v = v.Key("Parent") {
+		if r := v.Key(key); !r.IsNull() {
+			return r
+		}
+	}
+	return pdf.Value{}
+}
+
+// matrix is a PDF transformation matrix [a b c d e f]
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+func translate(tx, ty float64) matrix {
+	return matrix{1, 0, 0, 1, tx, ty}
+}
+
+// textState is the part of the graphics state that places glyphs
+type textState struct {
+	ctm                  matrix
+	font                 *font
+	size                 float64
+	charSpace, wordSpace float64
+	scale, leading, rise float64
+}
+
+// readGlyphs runs a content stream and returns the glyphs it draws
+func readGlyphs(contents pdf.Value, fonts map[string]*font) []glyph {
+	var glyphs []glyph
+	g := textState{ctm: identity, scale: 1}
+	var stack []textState
+	var tm, tlm matrix
+
+	show := func(s string) {
+		if g.font == nil {
+			return
+		}
+		for _, c := range g.font.decode(s) {
+			trm := matrix{g.size * g.scale, 0, 0, g.size, 0, g.rise}.mul(tm).mul(g.ctm)
+			tx := c.width*g.size + g.charSpace
+			if c.code == " " {
+				tx += g.wordSpace
+			}
+			tm = translate(tx*g.scale, 0).mul(tm)
+			end := matrix{g.size * g.scale, 0, 0, g.size, 0, g.rise}.mul(tm).mul(g.ctm)
+			glyphs = append(glyphs, glyph{
+				x:    trm[4],
+				end:  end[4],
+				y:    trm[5],
+				size: math.Hypot(trm[2], trm[3]),
+				text: c.text,
+			})
+		}
+	}
+
+	pdf.Interpret(contents, func(stk *pdf.Stack, op string) {
+		args := make([]pdf.Value, stk.Len())
+		for i := len(args) - 1; i >= 0; i-- {
+			args[i] = stk.Pop()
+		}
+		num := func(i int) float64 {
+			if i < len(args) {
+				return args[i].Float64()
+			}
+			return 0
+		}
+		mat := func() matrix {
+			var m matrix
+			for i := range m {
+				m[i] = num(i)
+			}
+			return m
+		}
+
+		switch op {
+		case "q":
+			stack = append(stack, g)
+		case "Q":
+			if n := len(stack); n > 0 {
+				g, stack = stack[n-1], stack[:n-1]
+			}
+		case "cm":
+			g.ctm = mat().mul(g.ctm)
+		case "BT":
+			tm, tlm = identity, identity
+		case "Tm":
+			tm = mat()
+			tlm = tm
+		case "TD":
+			g.leading = -num(1)
+			fallthrough
+		case "Td":
+			tlm = translate(num(0), num(1)).mul(tlm)
+			tm = tlm
+		case "T*":
+			tlm = translate(0, -g.leading).mul(tlm)
+			tm = tlm
+		case "TL":
+			g.leading = num(0)
+		case "Tc":
+			g.charSpace = num(0)
+		case "Tw":
+			g.wordSpace = num(0)
+		case "Tz":
+			g.scale = num(0) / 100
+		case "Ts":
+			g.rise = num(0)
+		case "Tf":
+			if len(args) == 2 {
+				g.font = fonts[args[0].Name()]
+				g.size = num(1)
+			}
+		case "Tj":
+			if len(args) == 1 {
+				show(args[0].RawString())
+			}
+		case "'", "\"":
+			if op == "\"" && len(args) == 3 {
+				g.wordSpace, g.charSpace = num(0), num(1)
+				args = args[2:]
+			}
+			tlm = translate(0, -g.leading).mul(tlm)
+			tm = tlm
+			if len(args) == 1 {
+				show(args[0].RawString())
+			}
+		case "TJ":
+			if len(args) != 1 {
+				return
+			}
+			for i := 0; i < args[0].Len(); i++ {
+				item := args[0].Index(i)
+				if item.Kind() == pdf.String {
+					show(item.RawString())
+				} else {
+					tm = translate(-item.Float64()/1000*g.size*g.scale, 0).mul(tm)
+				}
+			}
+		}
+	})
+
+	return glyphs
+}
+
+// readLinks collects the URI link annotations of a page with the text under
+// each. It runs before layoutLines, which reorders the glyphs.
+func readLinks(annots pdf.Value, glyphs []glyph) []Link {
+	var links []Link
+	for i := 0; i < annots.Len(); i++ {
+		a := annots.Index(i)
+		action := a.Key("A")
+		if a.Key("Subtype").Name() != "Link" || action.Key("S").Name() != "URI" {
+			continue
+		}
+		rect := a.Key("Rect")
+		x0, y0 := rect.Index(0).Float64(), rect.Index(1).Float64()
+		x1, y1 := rect.Index(2).Float64(), rect.Index(3).Float64()
+		link := Link{
+			X0:  math.Min(x0, x1),
+			X1:  math.Max(x0, x1),
+			Y:   math.Min(y0, y1),
+			URI: strings.TrimSpace(action.Key("URI").RawString()),
+		}
+
+		var covered []glyph
+		for _, g := range glyphs {
+			mid := (g.x + g.end) / 2
+			if mid >= link.X0 && mid <= link.X1 && g.y >= math.Min(y0, y1) && g.y <= math.Max(y0, y1) {
+				covered = append(covered, g)
+			}
+		}
+		if len(covered) > 0 {
+			lines := layoutLines(covered)
+			link.Y = lines[0].Y
+			texts := make([]string, len(lines))
+			for j, l := range lines {
+				texts[j] = l.Text()
+			}
+			link.Text = strings.Join(texts, " ")
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// layoutLines groups glyphs into lines by baseline, top to bottom, and
+// infers word spaces and segments from the gaps between glyphs
+func layoutLines(glyphs []glyph) []Line {
+	sort.SliceStable(glyphs, func(i, j int) bool {
+		return glyphs[i].y > glyphs[j].y
+	})
+
+	var lines []Line
+	for start := 0; start < len(glyphs); {
+		end := start + 1
+		for end < len(glyphs) && glyphs[start].y-glyphs[end].y <= lineJitter {
+			end++
+		}
+		row := glyphs[start:end]
+		sort.SliceStable(row, func(i, j int) bool { return row[i].x < row[j].x })
+		lines = append(lines, buildLine(row))
+		start = end
+	}
+	return lines
+}
+
+func buildLine(row []glyph) Line {
+	line := Line{Y: row[0].y}
+	var seg *Segment
+	var sb strings.Builder
+	flush := func() {
+		if seg != nil {
+			seg.Text = strings.TrimSpace(sb.String())
+			if seg.Text != "" {
+				line.Segments = append(line.Segments, *seg)
+			}
+		}
+		sb.Reset()
+	}
+
+	prevEnd := math.Inf(-1)
+	for _, g := range row {
+		line.Size = math.Max(line.Size, g.size)
+		em := math.Max(g.size, 1)
+		gap := g.x - prevEnd
+		switch {
+		case seg == nil || gap > segmentGap*em:
+			flush()
+			seg = &Segment{X0: g.x}
+		case gap > spaceGap*em && !strings.HasSuffix(sb.String(), " ") && g.text != " ":
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(g.text)
+		seg.X1 = math.Max(seg.X1, g.end)
+		prevEnd = math.Max(prevEnd, g.end)
+	}
+	flush()
+	return line
+}
@@ -0,0 +1,399 @@
+package pdftext
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// font decodes the character codes of one PDF font
+type font struct {
+	info       Font
+	codeLen    int               // Bytes per character code
+	toUnicode  map[string]string // Character code -> text, from the ToUnicode map
+	encoding   map[int]string    // Character code -> glyph name, from /Differences
+	widths     map[int]float64   // Character code -> advance in glyph space
+	defaultW   float64
+	widthScale float64 // Glyph space to text space
+}
+
+// char is a decoded character code
+type char struct {
+	code  string
+	text  string
+	width float64 // Advance in text space units
+}
+
+func loadFont(v pdf.Value) *font {
+	name := v.Key("BaseFont").Name()
+	if i := strings.Index(name, "+"); i >= 0 {
+		name = name[i+1:] // Drop the subset tag, e.g. "ABCDEF+CMR10"
+	}
+
+	f := &font{
+		info:       Font{Name: name, Subtype: v.Key("Subtype").Name()},
+		codeLen:    1,
+		widths:     make(map[int]float64),
+		widthScale: 0.001,
+	}
+	if tu := v.Key("ToUnicode"); tu.Kind() == pdf.Stream {
+		f.toUnicode, f.codeLen = readCMap(tu)
+		f.info.ToUnicode = len(f.toUnicode) > 0
+	}
+
+	switch f.info.Subtype {
+	case "Type0":
+		// Composite fonts use two-byte codes (Identity-H) and CID widths
+		f.codeLen = 2
+		desc := v.Key("DescendantFonts").Index(0)
+		f.defaultW = 1000
+		if dw := desc.Key("DW"); dw.Kind() != pdf.Null {
+			f.defaultW = dw.Float64()
+		}
+		readCIDWidths(desc.Key("W"), f.widths)
+	default:
+		if f.info.Subtype == "Type3" {
+			f.widthScale = v.Key("FontMatrix").Index(0).Float64()
+		}
+		first := int(v.Key("FirstChar").Int64())
+		ws := v.Key("Widths")
+		for i := 0; i < ws.Len(); i++ {
+			f.widths[first+i] = ws.Index(i).Float64()
+		}
+		if enc := v.Key("Encoding"); enc.Kind() == pdf.Dict {
+			f.encoding = readDifferences(enc.Key("Differences"))
+		}
+	}
+	return f
+}
+
+// decode splits a shown string into characters with their text and width
+func (f *font) decode(s string) []char {
+	var chars []char
+	for len(s) > 0 {
+		n := min(f.codeLen, len(s))
+		code := s[:n]
+		s = s[n:]
+
+		cid := 0
+		for i := 0; i < n; i++ {
+			cid = cid<<8 | int(code[i])
+		}
+		w, ok := f.widths[cid]
+		if !ok {
+			w = f.defaultW
+		}
+		chars = append(chars, char{code: code, text: f.text(code, cid), width: w * f.widthScale})
+	}
+	return chars
+}
+
+// text maps a character code to Unicode the way a parser without font
+// program access must: the ToUnicode map, then the encoding's glyph names,
+// then the code itself. Composite fonts have no fallback.
+func (f *font) text(code string, cid int) string {
+	if t, ok := f.toUnicode[code]; ok {
+		return t
+	}
+	if name, ok := f.encoding[cid]; ok {
+		return glyphText(name)
+	}
+	if f.codeLen > 1 {
+		return "�"
+	}
+	if r, ok := winAnsiHigh[cid]; ok {
+		return string(r)
+	}
+	return string(rune(cid))
+}
+
+// winAnsiHigh covers the WinAnsiEncoding codes that differ from Latin-1
+var winAnsiHigh = map[int]rune{
+	0x80: '€', 0x85: '…', 0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”',
+	0x95: '•', 0x96: '–', 0x97: '—', 0x99: '™',
+}
+
+// readDifferences reads an encoding's /Differences array into code -> glyph name
+func readDifferences(diff pdf.Value) map[int]string {
+	names := make(map[int]string)
+	code := 0
+	for i := 0; i < diff.Len(); i++ {
+		item := diff.Index(i)
+		if item.Kind() == pdf.Integer {
+			code = int(item.Int64())
+			continue
+		}
+		names[code] = item.Name()
+		code++
+	}
+	return names
+}
+
+// readCIDWidths reads a composite font's /W array: "c [w1 w2 ...]" lists
+// consecutive widths and "c1 c2 w" gives a range one width
+func readCIDWidths(w pdf.Value, widths map[int]float64) {
+	for i := 0; i < w.Len(); {
+		first := int(w.Index(i).Int64())
+		if i+1 < w.Len() && w.Index(i+1).Kind() == pdf.Array {
+			list := w.Index(i + 1)
+			for j := 0; j < list.Len(); j++ {
+				widths[first+j] = list.Index(j).Float64()
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= w.Len() {
+			return
+		}
+		last := int(w.Index(i + 1).Int64())
+		for c := first; c <= last && c-first < 1<<16; c++ {
+			widths[c] = w.Index(i + 2).Float64()
+		}
+		i += 3
+	}
+}
+
+// maxRangeSize bounds how many codes a single bfrange may expand to
+const maxRangeSize = 1 << 16
+
+// readCMap reads a ToUnicode CMap into code -> text and reports the code
+// length its codespace declares
+func readCMap(stream pdf.Value) (map[string]string, int) {
+	m := make(map[string]string)
+	codeLen := 1
+
+	rd := stream.Reader()
+	defer rd.Close()
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return m, codeLen
+	}
+
+	// Operands accumulate until a keyword consumes them
+	var operands []cmapToken
+	for _, tok := range tokenizeCMap(string(data)) {
+		if tok.kind != cmapKeyword {
+			operands = append(operands, tok)
+			continue
+		}
+		switch tok.text {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				codeLen = max(codeLen, len(operands[i].text))
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				m[operands[i].text] = decodeUTF16(operands[i+1].text)
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); {
+				lo, hi := operands[i].text, operands[i+1].text
+				if operands[i+2].kind == cmapArrayStart {
+					var dst []string
+					for i += 3; i < len(operands) && operands[i].kind != cmapArrayEnd; i++ {
+						dst = append(dst, operands[i].text)
+					}
+					i++
+					expandRange(m, lo, hi, "", dst)
+					continue
+				}
+				expandRange(m, lo, hi, operands[i+2].text, nil)
+				i += 3
+			}
+		}
+		operands = operands[:0]
+	}
+	return m, codeLen
+}
+
+// CMap token kinds
+const (
+	cmapString = iota // Hex string, decoded to bytes
+	cmapKeyword
+	cmapArrayStart
+	cmapArrayEnd
+	cmapOther // Numbers, names and literal strings
+)
+
+type cmapToken struct {
+	kind int
+	text string
+}
+
+// tokenizeCMap splits CMap source into the tokens needed for its mappings
+func tokenizeCMap(src string) []cmapToken {
+	var tokens []cmapToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '%':
+			for i < len(src) && src[i] != '\n' && src[i] != '\r' {
+				i++
+			}
+		case c == '<' && i+1 < len(src) && src[i+1] == '<', c == '>' && i+1 < len(src) && src[i+1] == '>':
+			i += 2
+		case c == '<':
+			end := strings.IndexByte(src[i:], '>')
+			if end < 0 {
+				return tokens
+			}
+			tokens = append(tokens, cmapToken{cmapString, hexBytes(src[i+1 : i+end])})
+			i += end + 1
+		case c == '[':
+			tokens = append(tokens, cmapToken{kind: cmapArrayStart})
+			i++
+		case c == ']':
+			tokens = append(tokens, cmapToken{kind: cmapArrayEnd})
+			i++
+		case c == '(':
+			end := strings.IndexByte(src[i:], ')')
+			if end < 0 {
+				return tokens
+			}
+			tokens = append(tokens, cmapToken{cmapOther, src[i+1 : i+end]})
+			i += end + 1
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == 0:
+			i++
+		default:
+			start := i
+			for i < len(src) && !strings.ContainsRune(" \t\n\r\f\x00<>[]()/%{}", rune(src[i])) || i == start {
+				i++
+			}
+			word := src[start:i]
+			kind := cmapOther
+			if c != '/' && (c < '0' || c > '9') && c != '-' && c != '.' {
+				kind = cmapKeyword
+			}
+			tokens = append(tokens, cmapToken{kind, word})
+		}
+	}
+	return tokens
+}
+
+// hexBytes decodes the digits of a hex string, padding an odd final digit
+func hexBytes(h string) string {
+	var digits []byte
+	for i := 0; i < len(h); i++ {
+		if strings.IndexByte("0123456789abcdefABCDEF", h[i]) >= 0 {
+			digits = append(digits, h[i])
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	b := make([]byte, len(digits)/2)
+	for i := range b {
+		v, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		b[i] = byte(v)
+	}
+	return string(b)
+}
+
+// expandRange maps the codes lo..hi either to consecutive text starting at
+// dst or to the texts listed in dsts
+func expandRange(m map[string]string, lo, hi, dst string, dsts []string) {
+	if len(lo) == 0 || len(lo) != len(hi) {
+		return
+	}
+	from, to := codeValue(lo), codeValue(hi)
+	if to < from || to-from >= maxRangeSize {
+		return
+	}
+	for c := from; c <= to; c++ {
+		src := codeString(c, len(lo))
+		if dsts != nil {
+			if i := c - from; i < len(dsts) {
+				m[src] = decodeUTF16(dsts[i])
+			}
+			continue
+		}
+		// Increment the last UTF-16 unit of the destination
+		units := utf16Units(dst)
+		if len(units) == 0 {
+			return
+		}
+		units[len(units)-1] += uint16(c - from)
+		m[src] = string(utf16.Decode(units))
+	}
+}
+
+func codeValue(s string) int {
+	v := 0
+	for i := 0; i < len(s); i++ {
+		v = v<<8 | int(s[i])
+	}
+	return v
+}
+
+func codeString(v, n int) string {
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return string(b)
+}
+
+func utf16Units(s string) []uint16 {
+	units := make([]uint16, len(s)/2)
+	for i := range units {
+		units[i] = uint16(s[2*i])<<8 | uint16(s[2*i+1])
+	}
+	return units
+}
+
+func decodeUTF16(s string) string {
+	return string(utf16.Decode(utf16Units(s)))
+}
+
+// glyphText maps an Adobe glyph name to text. Ligature glyphs map to their
+// single presentation-form code points, which is what parsers without a
+// ToUnicode map produce.
+func glyphText(name string) string {
+	if r, ok := glyphNames[name]; ok {
+		return string(r)
+	}
+	if len(name) == 1 {
+		return name
+	}
+	for _, prefix := range []string{"uni", "u"} {
+		if hex, ok := strings.CutPrefix(name, prefix); ok && len(hex) >= 4 && len(hex) <= 6 {
+			if r, err := strconv.ParseUint(hex[:4], 16, 32); err == nil && prefix == "uni" {
+				return string(rune(r))
+			}
+			if r, err := strconv.ParseUint(hex, 16, 32); err == nil {
+				return string(rune(r))
+			}
+		}
+	}
+	return "�"
+}
+
+var glyphNames = map[string]rune{
+	"space": ' ', "exclam": '!', "quotedbl": '"', "numbersign": '#', "dollar": '$',
+	"percent": '%', "ampersand": '&', "quotesingle": '\'', "quoteright": '’', "quoteleft": '‘',
+	"parenleft": '(', "parenright": ')', "asterisk": '*', "plus": '+', "comma": ',',
+	"hyphen": '-', "period": '.', "slash": '/', "colon": ':', "semicolon": ';',
+	"less": '<', "equal": '=', "greater": '>', "question": '?', "at": '@',
+	"bracketleft": '[', "backslash": '\\', "bracketright": ']', "asciicircum": '^',
+	"underscore": '_', "grave": '`', "braceleft": '{', "bar": '|', "braceright": '}',
+	"asciitilde": '~', "endash": '–', "emdash": '—', "bullet": '•', "periodcentered": '·',
+	"quotedblleft": '“', "quotedblright": '”', "quotedblbase": '„', "quotesinglbase": '‚',
+	"ellipsis": '…', "dagger": '†', "daggerdbl": '‡', "section": '§', "paragraph": '¶',
+	"copyright": '©', "registered": '®', "trademark": '™', "degree": '°', "minus": '−',
+	"multiply": '×', "divide": '÷', "sterling": '£', "yen": '¥', "Euro": '€',
+	"zero": '0', "one": '1', "two": '2', "three": '3', "four": '4',
+	"five": '5', "six": '6', "seven": '7', "eight": '8', "nine": '9',
+	"dotlessi": 'ı', "germandbls": 'ß', "ae": 'æ', "AE": 'Æ', "oe": 'œ', "OE": 'Œ',
+	"oslash": 'ø', "Oslash": 'Ø', "lslash": 'ł', "Lslash": 'Ł',
+	"acute": '´', "dieresis": '¨', "circumflex": 'ˆ', "tilde": '˜', "cedilla": '¸',
+	"caron": 'ˇ', "breve": '˘', "ring": '˚', "macron": '¯', "dotaccent": '˙',
+	"ff": 'ﬀ', "fi": 'ﬁ', "fl": 'ﬂ', "ffi": 'ﬃ', "ffl": 'ﬄ',
+	"eacute": 'é', "egrave": 'è', "ecircumflex": 'ê', "aacute": 'á', "agrave": 'à',
+	"acircumflex": 'â', "adieresis": 'ä', "odieresis": 'ö', "udieresis": 'ü',
+	"ccedilla": 'ç', "ntilde": 'ñ', "Eacute": 'É', "Adieresis": 'Ä', "Odieresis": 'Ö',
+	"Udieresis": 'Ü',
+}
@@ -0,0 +1,152 @@
+package phone
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// ErrInvalid is returned when a phone number is not a valid E.164 number
+var ErrInvalid = errors.New("invalid E.164 phone number")
+
+// Number is a parsed E.164 phone number
+type Number struct {
+	CountryCode string // Calling code without the leading "+"
+	National    string // Subscriber number without the calling code
+	country     *country
+}
+
+// country describes how numbers for a calling code are grouped for display
+type country struct {
+	lengths []int                       // Valid national number lengths
+	groups  func(national string) []int // Digit groups for the national number
+	format  func(groups []string) string
+}
+
+// fixed returns a grouping function that always uses the same digit groups
+func fixed(groups ...int) func(string) []int {
+	return func(string) []int { return groups }
+}
+
+// spaced joins the groups with single spaces
+func spaced(groups []string) string {
+	return strings.Join(groups, " ")
+}
+
+// countries maps calling codes to their national numbering conventions.
+// Calling codes are prefix-free, so at most one entry matches a number.
+var countries = map[string]*country{
+	// North American Numbering Plan (Canada, US, Caribbean)
+	"1": {lengths: []int{10}, groups: fixed(3, 3, 4), format: func(g []string) string {
+		return "(" + g[0] + ") " + g[1] + "-" + g[2]
+	}},
+	"7":  {lengths: []int{10}, groups: fixed(3, 3, 2, 2), format: spaced},
+	"27": {lengths: []int{9}, groups: fixed(2, 3, 4), format: spaced},
+	"31": {lengths: []int{9}, groups: fixed(1, 4, 4), format: spaced},
+	"33": {lengths: []int{9}, groups: fixed(1, 2, 2, 2, 2), format: spaced},
+	"34": {lengths: []int{9}, groups: fixed(3, 3, 3), format: spaced},
+	"39": {lengths: []int{9, 10, 11}, groups: func(n string) []int {
+		return []int{3, len(n) - 3}
+	}, format: spaced},
+	"44": {lengths: []int{10}, groups: func(n string) []int {
+		if strings.HasPrefix(n, "2") {
+			return []int{2, 4, 4} // London and other 02 area codes
+		}
+		return []int{4, 6}
+	}, format: spaced},
+	"49": {lengths: []int{10, 11}, groups: func(n string) []int {
+		if strings.HasPrefix(n, "1") {
+			return []int{3, len(n) - 3} // Mobile
+		}
+		return []int{2, len(n) - 2}
+	}, format: spaced},
+	"52": {lengths: []int{10}, groups: fixed(2, 4, 4), format: spaced},
+	"55": {lengths: []int{10, 11}, groups: func(n string) []int {
+		return []int{2, len(n) - 6, 4}
+	}, format: spaced},
+	"61": {lengths: []int{9}, groups: func(n string) []int {
+		if strings.HasPrefix(n, "4") {
+			return []int{3, 3, 3} // Mobile
+		}
+		return []int{1, 4, 4}
+	}, format: spaced},
+	"64": {lengths: []int{8, 9, 10}, groups: func(n string) []int {
+		return []int{2, 3, len(n) - 5}
+	}, format: spaced},
+	"65":  {lengths: []int{8}, groups: fixed(4, 4), format: spaced},
+	"81":  {lengths: []int{10}, groups: fixed(2, 4, 4), format: spaced},
+	"86":  {lengths: []int{11}, groups: fixed(3, 4, 4), format: spaced},
+	"91":  {lengths: []int{10}, groups: fixed(5, 5), format: spaced},
+	"353": {lengths: []int{9}, groups: fixed(2, 3, 4), format: spaced},
+	"971": {lengths: []int{9}, groups: fixed(2, 3, 4), format: spaced},
+}
+
+// Parse validates an E.164 phone number. Spaces, dashes, dots and
+// parentheses are accepted as separators and stripped.
+func Parse(s string) (Number, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "+") {
+		return Number{}, ErrInvalid
+	}
+
+	var digits strings.Builder
+	for _, r := range s[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+			// Separator, ignore
+		default:
+			return Number{}, ErrInvalid
+		}
+	}
+
+	// E.164 allows at most 15 digits and calling codes never start with 0
+	d := digits.String()
+	if len(d) < 8 || len(d) > 15 || d[0] == '0' {
+		return Number{}, ErrInvalid
+	}
+
+	for size := 1; size <= 3; size++ {
+		c, ok := countries[d[:size]]
+		if !ok {
+			continue
+		}
+		national := d[size:]
+		if !slices.Contains(c.lengths, len(national)) {
+			return Number{}, ErrInvalid
+		}
+		return Number{CountryCode: d[:size], National: national, country: c}, nil
+	}
+
+	// Unknown calling code: keep the digits as-is
+	return Number{National: d}, nil
+}
+
+// E164 returns the canonical form, e.g. "+14165551234"
+func (n Number) E164() string {
+	return "+" + n.CountryCode + n.National
+}
+
+// Format returns the international display form, e.g. "+1 (416) 555-1234".
+// Numbers with an unrecognized calling code are returned in E.164 form.
+func (n Number) Format() string {
+	if n.country == nil {
+		return n.E164()
+	}
+
+	var groups []string
+	rest := n.National
+	for _, size := range n.country.groups(rest) {
+		if size <= 0 || size > len(rest) {
+			break
+		}
+		groups = append(groups, rest[:size])
+		rest = rest[size:]
+	}
+	if rest != "" {
+		groups = append(groups, rest)
+	}
+
+	return "+" + n.CountryCode + " " + n.country.format(groups)
+}
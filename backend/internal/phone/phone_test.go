@@ -0,0 +1,55 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		e164    string
+		display string
+	}{
+		{"+14165551234", "+14165551234", "+1 (416) 555-1234"},
+		{" +1 (416) 555-1234 ", "+14165551234", "+1 (416) 555-1234"},
+		{"+44 20 7946 0958", "+442079460958", "+44 20 7946 0958"},
+		{"+447911123456", "+447911123456", "+44 7911 123456"},
+		{"+49 151 23456789", "+4915123456789", "+49 151 23456789"},
+		{"+61.412.345.678", "+61412345678", "+61 412 345 678"},
+		{"+919876543210", "+919876543210", "+91 98765 43210"},
+		{"+2901234567", "+2901234567", "+2901234567"}, // Unknown calling code
+	}
+	for _, tt := range tests {
+		n, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got := n.E164(); got != tt.e164 {
+			t.Errorf("Parse(%q).E164() = %q, want %q", tt.in, got, tt.e164)
+		}
+		if got := n.Format(); got != tt.display {
+			t.Errorf("Parse(%q).Format() = %q, want %q", tt.in, got, tt.display)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"4165551234",         // No leading +
+		"+1 416 555 123",     // Too short for NANP
+		"+1 416 555 12345",   // Too long for NANP
+		"+0123456789",        // Calling codes never start with 0
+		"+1234",              // Too short for E.164
+		"+1234567890123456",  // Longer than 15 digits
+		"+1 416 555 1234 x2", // Extension
+		"+1/416/555/1234",
+		"+3531234567890", // Ireland takes 9 national digits
+	} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalid", in, err)
+		}
+	}
+}
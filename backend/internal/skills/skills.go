@@ -0,0 +1,251 @@
+package skills
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// Proficiency levels, from strongest to weakest
+const (
+	LevelExpert       = "expert"
+	LevelAdvanced     = "advanced"
+	LevelIntermediate = "intermediate"
+	LevelBeginner     = "beginner"
+)
+
+// Levels lists the proficiency levels from strongest to weakest
+var Levels = []string{LevelExpert, LevelAdvanced, LevelIntermediate, LevelBeginner}
+
+// Skill is a single parsed skill
+type Skill struct {
+	Name  string
+	Level string // One of Levels, or empty when unspecified
+}
+
+// levelSuffix matches a trailing parenthetical such as "Go (Expert)"
+var levelSuffix = regexp.MustCompile(`^(.*?)\s*\(([^()]*)\)$`)
+
+// ValidLevel reports whether s is a known proficiency level or empty
+func ValidLevel(s string) bool {
+	if s == "" {
+		return true
+	}
+	_, ok := levelAliases[strings.ToLower(strings.TrimSpace(s))]
+	return ok
+}
+
+// levelAliases maps accepted level spellings to the canonical level
+var levelAliases = map[string]string{
+	"expert":       LevelExpert,
+	"proficient":   LevelAdvanced,
+	"advanced":     LevelAdvanced,
+	"intermediate": LevelIntermediate,
+	"familiar":     LevelBeginner,
+	"beginner":     LevelBeginner,
+	"basic":        LevelBeginner,
+}
+
+// NormalizeLevel returns the canonical level for an accepted spelling
+func NormalizeLevel(s string) string {
+	return levelAliases[strings.ToLower(strings.TrimSpace(s))]
+}
+
+// Parse splits a comma-separated skills string. Commas inside parentheses
+// do not split, so "Databases (PostgreSQL, MySQL)" stays one skill. A
+// trailing parenthetical is read as a proficiency level only when it names a
+// known level, so "Go (Expert)" carries a level while "C++ (STL)" stays a
+// plain name.
+func Parse(s string) []Skill {
+	var result []Skill
+	for _, item := range splitTopLevel(s) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		skill := Skill{Name: item}
+		if m := levelSuffix.FindStringSubmatch(item); m != nil && m[1] != "" {
+			if level := NormalizeLevel(m[2]); level != "" {
+				skill = Skill{Name: m[1], Level: level}
+			}
+		}
+		result = append(result, skill)
+	}
+	return result
+}
+
+// splitTopLevel splits s at commas that are not nested in brackets
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// Canonical returns the canonical spelling of a skill name from the built-in
+// dictionary, or the trimmed name when it is not listed
+func Canonical(name string) string {
+	name = strings.TrimSpace(name)
+	if canonical, ok := dictionary[strings.ToLower(name)]; ok {
+		return canonical
+	}
+	return name
+}
+
+// Clean canonicalizes names (when normalize is set) and removes duplicates
+// case-insensitively. The first occurrence keeps its position and takes the
+// level of a later duplicate when it has none of its own.
+func Clean(list []Skill, normalize bool) []Skill {
+	var result []Skill
+	index := make(map[string]int)
+
+	for _, skill := range list {
+		skill.Name = strings.TrimSpace(skill.Name)
+		if normalize {
+			skill.Name = Canonical(skill.Name)
+		}
+		skill.Level = NormalizeLevel(skill.Level)
+		if skill.Name == "" {
+			continue
+		}
+
+		key := strings.ToLower(skill.Name)
+		if i, ok := index[key]; ok {
+			if result[i].Level == "" {
+				result[i].Level = skill.Level
+			}
+			continue
+		}
+		index[key] = len(result)
+		result = append(result, skill)
+	}
+
+	return result
+}
+
+// dictionary maps lowercase aliases to canonical skill names, as ATS keyword
+// matching depends on the canonical spelling. Everyday words are only listed
+// when the canonical name differs from them in case alone, so "spring" or
+// "shell" are never rewritten into a specific product.
+var dictionary = map[string]string{
+	// Languages
+	"go": "Go", "golang": "Go",
+	"python": "Python", "python3": "Python",
+	"javascript": "JavaScript", "js": "JavaScript", "ecmascript": "JavaScript", "typescript": "TypeScript",
+	"java": "Java", "kotlin": "Kotlin", "swift": "Swift", "rust": "Rust", "ruby": "Ruby",
+	"c++": "C++", "cpp": "C++", "c#": "C#", "csharp": "C#", "c sharp": "C#",
+	"objective-c": "Objective-C", "objc": "Objective-C",
+	"php": "PHP", "scala": "Scala", "r": "R", "matlab": "MATLAB", "perl": "Perl",
+	"sql": "SQL", "nosql": "NoSQL", "bash": "Bash", "shell scripting": "Shell scripting",
+	"html": "HTML", "html5": "HTML", "css": "CSS", "css3": "CSS", "sass": "Sass", "scss": "Sass",
+
+	// Frameworks and libraries
+	"react": "React", "reactjs": "React", "react.js": "React",
+	"react native": "React Native", "vue": "Vue.js", "vuejs": "Vue.js", "vue.js": "Vue.js",
+	"angular": "Angular", "angularjs": "AngularJS",
+	"nextjs": "Next.js", "next.js": "Next.js",
+	"nodejs": "Node.js", "node.js": "Node.js",
+	"express": "Express", "expressjs": "Express", "express.js": "Express",
+	"django": "Django", "flask": "Flask", "fastapi": "FastAPI",
+	"springboot": "Spring Boot", "spring boot": "Spring Boot",
+	"rails": "Ruby on Rails", "ror": "Ruby on Rails", "ruby on rails": "Ruby on Rails",
+	".net": ".NET", "dotnet": ".NET", "asp.net": "ASP.NET",
+	"gin": "Gin", "graphql": "GraphQL", "rest": "REST", "restful": "REST", "rest api": "REST",
+	"grpc": "gRPC", "protobuf": "Protocol Buffers", "tailwind": "Tailwind CSS", "tailwindcss": "Tailwind CSS",
+	"tensorflow": "TensorFlow", "pytorch": "PyTorch", "keras": "Keras",
+	"sklearn": "scikit-learn", "scikit-learn": "scikit-learn", "scikit learn": "scikit-learn",
+	"pandas": "pandas", "numpy": "NumPy", "jupyter": "Jupyter",
+
+	// Data stores
+	"postgres": "PostgreSQL", "postgresql": "PostgreSQL", "psql": "PostgreSQL",
+	"mysql": "MySQL", "sqlite": "SQLite", "mongo": "MongoDB", "mongodb": "MongoDB",
+	"redis": "Redis", "dynamodb": "DynamoDB", "cassandra": "Apache Cassandra", "elasticsearch": "Elasticsearch",
+	"kafka": "Apache Kafka", "apache kafka": "Apache Kafka",
+	"apache spark": "Apache Spark", "pyspark": "PySpark", "hadoop": "Apache Hadoop",
+	"airflow": "Apache Airflow", "snowflake": "Snowflake", "bigquery": "BigQuery", "dbt": "dbt",
+
+	// Cloud and infrastructure
+	"aws": "AWS", "amazon web services": "AWS", "gcp": "Google Cloud", "google cloud platform": "Google Cloud",
+	"azure": "Microsoft Azure", "microsoft azure": "Microsoft Azure",
+	"s3": "Amazon S3", "ec2": "Amazon EC2", "aws lambda": "AWS Lambda",
+	"eks": "Amazon EKS", "gke": "GKE",
+	"k8s": "Kubernetes", "kubernetes": "Kubernetes", "kube": "Kubernetes",
+	"docker": "Docker", "helm": "Helm", "terraform": "Terraform",
+	"ansible": "Ansible", "jenkins": "Jenkins", "github actions": "GitHub Actions", "gha": "GitHub Actions",
+	"ci/cd": "CI/CD", "cicd": "CI/CD", "ci cd": "CI/CD",
+	"prometheus": "Prometheus", "grafana": "Grafana", "linux": "Linux", "unix": "Unix",
+	"git": "Git", "nginx": "NGINX",
+
+	// Practices and tools
+	"ml": "Machine Learning", "machine learning": "Machine Learning", "deep learning": "Deep Learning",
+	"nlp": "NLP", "llm": "LLMs", "llms": "LLMs",
+	"oop": "OOP", "tdd": "TDD", "agile": "Agile", "scrum": "Scrum",
+	"microservices": "Microservices", "micro-services": "Microservices",
+	"jira": "Jira", "figma": "Figma", "latex": "LaTeX", "tableau": "Tableau",
+	"power bi": "Power BI", "powerbi": "Power BI", "excel": "Excel",
+}
+
+// Group is a named category of cleaned skills
+type Group struct {
+	Name   string
+	Skills []Skill
+}
+
+// FromCategories parses and cleans the skills of each category, dropping
+// skills already listed in an earlier category and categories left empty
+func FromCategories(categories []models.SkillCategory, normalize bool) []Group {
+	var groups []Group
+	seen := make(map[string]bool)
+
+	for _, cat := range categories {
+		list := Parse(cat.Skills)
+		for _, item := range cat.Items {
+			list = append(list, Skill{Name: item.Name, Level: item.Level})
+		}
+
+		var kept []Skill
+		for _, skill := range Clean(list, normalize) {
+			key := strings.ToLower(skill.Name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			kept = append(kept, skill)
+		}
+		if len(kept) > 0 {
+			groups = append(groups, Group{Name: strings.TrimSpace(cat.Name), Skills: kept})
+		}
+	}
+
+	return groups
+}
+
+// Names returns the skill names of a group list in order
+func Names(list []Skill) []string {
+	names := make([]string, len(list))
+	for i, skill := range list {
+		names[i] = skill.Name
+	}
+	return names
+}
+
+// Lookup reports the canonical name of a skill listed in the dictionary
+func Lookup(name string) (string, bool) {
+	canonical, ok := dictionary[strings.ToLower(strings.TrimSpace(name))]
+	return canonical, ok
+}
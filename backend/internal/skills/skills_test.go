@@ -0,0 +1,79 @@
+package skills
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Skill
+	}{
+		{"", nil},
+		{"Go, Python ,, Rust", []Skill{{Name: "Go"}, {Name: "Python"}, {Name: "Rust"}}},
+		{"Go (Expert), k8s (proficient)", []Skill{{Name: "Go", Level: LevelExpert}, {Name: "k8s", Level: LevelAdvanced}}},
+		{"C++ (STL), Java", []Skill{{Name: "C++ (STL)"}, {Name: "Java"}}},
+		{"Databases (PostgreSQL, MySQL), Redis", []Skill{{Name: "Databases (PostgreSQL, MySQL)"}, {Name: "Redis"}}},
+		{"Cloud [AWS, GCP] (Familiar)", []Skill{{Name: "Cloud [AWS, GCP]", Level: LevelBeginner}}},
+		{"Unbalanced ), Go", []Skill{{Name: "Unbalanced )"}, {Name: "Go"}}},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestClean(t *testing.T) {
+	list := []Skill{
+		{Name: " golang "},
+		{Name: "Spring"},
+		{Name: "tf"},
+		{Name: "GO", Level: "Expert"},
+		{Name: "postgres"},
+	}
+
+	got := Clean(list, false)
+	want := []Skill{{Name: "golang"}, {Name: "Spring"}, {Name: "tf"}, {Name: "GO", Level: LevelExpert}, {Name: "postgres"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Clean without normalizing = %+v, want %+v", got, want)
+	}
+
+	got = Clean(list, true)
+	want = []Skill{{Name: "Go", Level: LevelExpert}, {Name: "Spring"}, {Name: "tf"}, {Name: "PostgreSQL"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Clean with normalizing = %+v, want %+v", got, want)
+	}
+}
+
+func TestCanonicalLeavesAmbiguousWords(t *testing.T) {
+	for _, name := range []string{"spring", "tf", "shell", "lambda", "ts", "next", "elastic", "node"} {
+		if got := Canonical(name); got != name {
+			t.Errorf("Canonical(%q) = %q, want it unchanged", name, got)
+		}
+	}
+	for name, want := range map[string]string{"golang": "Go", "k8s": "Kubernetes", "nodejs": "Node.js", "spring boot": "Spring Boot"} {
+		if got := Canonical(name); got != want {
+			t.Errorf("Canonical(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFromCategories(t *testing.T) {
+	categories := []models.SkillCategory{
+		{Name: "Languages", Skills: "Go (Expert), Python"},
+		{Name: "Tools", Skills: "python, Docker", Items: []models.SkillItem{{Name: "Git", Level: "basic"}}},
+		{Name: "Empty", Skills: " , "},
+	}
+	got := FromCategories(categories, false)
+	want := []Group{
+		{Name: "Languages", Skills: []Skill{{Name: "Go", Level: LevelExpert}, {Name: "Python"}}},
+		{Name: "Tools", Skills: []Skill{{Name: "Docker"}, {Name: "Git", Level: LevelBeginner}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromCategories = %+v, want %+v", got, want)
+	}
+}
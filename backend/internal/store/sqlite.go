@@ -0,0 +1,418 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// migrations are applied in order; PRAGMA user_version records how many
+// have run. Append new migrations, never edit released ones.
+var migrations = []string{
+	`CREATE TABLE resumes (
+		id         TEXT PRIMARY KEY,
+		token_hash TEXT NOT NULL,
+		version    INTEGER NOT NULL,
+		data       TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL
+	)`,
+	`CREATE TABLE revisions (
+		resume_id  TEXT NOT NULL REFERENCES resumes(id) ON DELETE CASCADE,
+		version    INTEGER NOT NULL,
+		note       TEXT NOT NULL DEFAULT '',
+		data       TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		PRIMARY KEY (resume_id, version)
+	);
+	INSERT INTO revisions (resume_id, version, data, created_at)
+		SELECT id, version, data, updated_at FROM resumes`,
+	`CREATE TABLE variants (
+		id         TEXT PRIMARY KEY,
+		master_id  TEXT NOT NULL REFERENCES resumes(id) ON DELETE CASCADE,
+		version    INTEGER NOT NULL,
+		data       TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL
+	);
+	CREATE INDEX variants_master ON variants(master_id)`,
+}
+
+// SQLite is a Store backed by an embedded SQLite database file
+type SQLite struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens or creates the database at path and applies pending
+// migrations
+func OpenSQLite(path string) (*SQLite, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	dsn := "file:" + path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// A single connection serializes writers instead of failing with SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+
+	s := &SQLite{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *SQLite) migrate() error {
+	var applied int
+	if err := s.db.QueryRow("PRAGMA user_version").Scan(&applied); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for i := applied; i < len(migrations); i++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d failed: %w", i+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d failed: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %d failed: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Create saves a new resume at version 1 along with its first revision
+func (s *SQLite) Create(ctx context.Context, r *Resume, note string) error {
+	data, err := json.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	r.Version = 1
+	r.CreatedAt = now
+	r.UpdatedAt = now
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO resumes (id, token_hash, version, data, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		r.ID, r.TokenHash, r.Version, string(data), now.UnixMilli(), now.UnixMilli()); err != nil {
+		return err
+	}
+	if err := insertRevision(ctx, tx, r.ID, r.Version, note, data, now); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertRevision records a snapshot of the data saved as the given version
+func insertRevision(ctx context.Context, tx *sql.Tx, id string, version int, note string, data []byte, at time.Time) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO revisions (resume_id, version, note, data, created_at) VALUES (?, ?, ?, ?, ?)`,
+		id, version, note, string(data), at.UnixMilli())
+	return err
+}
+
+// Get loads a resume by ID
+func (s *SQLite) Get(ctx context.Context, id string) (*Resume, error) {
+	var r Resume
+	var data string
+	var created, updated int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, token_hash, version, data, created_at, updated_at FROM resumes WHERE id = ?`, id,
+	).Scan(&r.ID, &r.TokenHash, &r.Version, &data, &created, &updated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(data), &r.Data); err != nil {
+		return nil, fmt.Errorf("corrupt resume %s: %w", id, err)
+	}
+	r.CreatedAt = time.UnixMilli(created).UTC()
+	r.UpdatedAt = time.UnixMilli(updated).UTC()
+	return &r, nil
+}
+
+// Update replaces the data of a resume that is still at the given version
+// and records the new version as a revision
+func (s *SQLite) Update(ctx context.Context, id string, version int, data *models.ResumeRequest, note string) (*Resume, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE resumes SET data = ?, version = version + 1, updated_at = ? WHERE id = ? AND version = ?`,
+		string(encoded), now.UnixMilli(), id, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAffected(ctx, tx, res, id); err != nil {
+		return nil, err
+	}
+	if err := insertRevision(ctx, tx, id, version+1, note, encoded, now); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+// Delete removes a resume. Its revisions and variants are removed by the foreign key
+// cascade. A zero version deletes it at any version.
+func (s *SQLite) Delete(ctx context.Context, id string, version int) error {
+	query := `DELETE FROM resumes WHERE id = ?`
+	args := []interface{}{id}
+	if version != 0 {
+		query += ` AND version = ?`
+		args = append(args, version)
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	return checkAffected(ctx, s.db, res, id)
+}
+
+// Revisions lists the revisions of a resume, newest first, without their data
+func (s *SQLite) Revisions(ctx context.Context, id string) ([]Revision, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT version, note, created_at FROM revisions WHERE resume_id = ? ORDER BY version DESC`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []Revision
+	for rows.Next() {
+		var rev Revision
+		var created int64
+		if err := rows.Scan(&rev.Version, &rev.Note, &created); err != nil {
+			return nil, err
+		}
+		rev.CreatedAt = time.UnixMilli(created).UTC()
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, ErrNotFound
+	}
+	return revisions, nil
+}
+
+// Revision loads one revision of a resume
+func (s *SQLite) Revision(ctx context.Context, id string, version int) (*Revision, error) {
+	rev := Revision{Version: version}
+	var data string
+	var created int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT note, data, created_at FROM revisions WHERE resume_id = ? AND version = ?`, id, version,
+	).Scan(&rev.Note, &data, &created)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := s.Get(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(data), &rev.Data); err != nil {
+		return nil, fmt.Errorf("corrupt revision %d of resume %s: %w", version, id, err)
+	}
+	rev.CreatedAt = time.UnixMilli(created).UTC()
+	return &rev, nil
+}
+
+// CreateVariant saves a new variant of an existing resume at version 1
+func (s *SQLite) CreateVariant(ctx context.Context, v *Variant) error {
+	data, err := json.Marshal(v.Data)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	v.Version = 1
+	v.CreatedAt = now
+	v.UpdatedAt = now
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO variants (id, master_id, version, data, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		v.ID, v.MasterID, v.Version, string(data), now.UnixMilli(), now.UnixMilli())
+	if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+		return ErrNotFound
+	}
+	return err
+}
+
+// Variants lists the variants of a resume, oldest first
+func (s *SQLite) Variants(ctx context.Context, masterID string) ([]Variant, error) {
+	if _, err := s.Get(ctx, masterID); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, master_id, version, data, created_at, updated_at FROM variants WHERE master_id = ? ORDER BY created_at, id`, masterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := []Variant{}
+	for rows.Next() {
+		v, err := scanVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, *v)
+	}
+	return variants, rows.Err()
+}
+
+// Variant loads a variant of a resume
+func (s *SQLite) Variant(ctx context.Context, masterID, id string) (*Variant, error) {
+	v, err := scanVariant(s.db.QueryRowContext(ctx,
+		`SELECT id, master_id, version, data, created_at, updated_at FROM variants WHERE master_id = ? AND id = ?`, masterID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := s.Get(ctx, masterID); err != nil {
+			return nil, err
+		}
+		return nil, ErrVariantNotFound
+	}
+	return v, err
+}
+
+// UpdateVariant replaces the definition of a variant that is still at the
+// given version
+func (s *SQLite) UpdateVariant(ctx context.Context, masterID, id string, version int, data *models.Variant) (*Variant, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE variants SET data = ?, version = version + 1, updated_at = ? WHERE master_id = ? AND id = ? AND version = ?`,
+		string(encoded), time.Now().UTC().UnixMilli(), masterID, id, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkVariantAffected(ctx, res, masterID, id); err != nil {
+		return nil, err
+	}
+	return s.Variant(ctx, masterID, id)
+}
+
+// DeleteVariant removes a variant. A zero version deletes it at any version.
+func (s *SQLite) DeleteVariant(ctx context.Context, masterID, id string, version int) error {
+	query := `DELETE FROM variants WHERE master_id = ? AND id = ?`
+	args := []interface{}{masterID, id}
+	if version != 0 {
+		query += ` AND version = ?`
+		args = append(args, version)
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	return s.checkVariantAffected(ctx, res, masterID, id)
+}
+
+// checkVariantAffected tells a missing variant apart from a version mismatch
+// when a conditional statement changed no rows
+func (s *SQLite) checkVariantAffected(ctx context.Context, res sql.Result, masterID, id string) error {
+	n, err := res.RowsAffected()
+	if err != nil || n > 0 {
+		return err
+	}
+	if _, err := s.Variant(ctx, masterID, id); err != nil {
+		return err
+	}
+	return ErrVersionMismatch
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanVariant(row scanner) (*Variant, error) {
+	var v Variant
+	var data string
+	var created, updated int64
+	if err := row.Scan(&v.ID, &v.MasterID, &v.Version, &data, &created, &updated); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(data), &v.Data); err != nil {
+		return nil, fmt.Errorf("corrupt variant %s: %w", v.ID, err)
+	}
+	v.CreatedAt = time.UnixMilli(created).UTC()
+	v.UpdatedAt = time.UnixMilli(updated).UTC()
+	return &v, nil
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// checkAffected tells a missing resume apart from a version mismatch when a
+// conditional statement changed no rows
+func checkAffected(ctx context.Context, q queryer, res sql.Result, id string) error {
+	n, err := res.RowsAffected()
+	if err != nil || n > 0 {
+		return err
+	}
+	var exists int
+	err = q.QueryRowContext(ctx, `SELECT 1 FROM resumes WHERE id = ?`, id).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return ErrVersionMismatch
+}
+
+// Close releases the database
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+var (
+	// ErrNotFound is returned when no resume has the requested ID
+	ErrNotFound = errors.New("resume not found")
+	// ErrRevisionNotFound is returned when a resume has no such version
+	ErrRevisionNotFound = errors.New("revision not found")
+	// ErrVariantNotFound is returned when a resume has no variant with the
+	// requested ID
+	ErrVariantNotFound = errors.New("variant not found")
+	// ErrVersionMismatch is returned when a resume changed since the version
+	// the caller based its update on
+	ErrVersionMismatch = errors.New("resume has been modified")
+)
+
+// Resume is a resume document saved on the server. There are no accounts: a
+// resume is read through its unguessable ID and changed with the edit token
+// issued when it was created.
+type Resume struct {
+	ID        string
+	TokenHash string // Hex SHA-256 of the edit token; the token itself is never stored
+	Version   int    // Starts at 1 and increases with every update
+	Data      models.ResumeRequest
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Revision is an immutable snapshot of a resume, recorded on every save
+type Revision struct {
+	Version   int
+	Note      string // Optional, e.g. "Tailored for Acme"
+	Data      models.ResumeRequest
+	CreatedAt time.Time
+}
+
+// Variant is a tailored selection of a master resume. It holds only the
+// variant definition; the master's current data is applied when it is read.
+type Variant struct {
+	ID        string
+	MasterID  string
+	Version   int // Starts at 1 and increases with every update
+	Data      models.Variant
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Store persists resumes, their revision history and their variants
+type Store interface {
+	// Create saves a new resume at version 1
+	Create(ctx context.Context, r *Resume, note string) error
+	// Get loads a resume by ID
+	Get(ctx context.Context, id string) (*Resume, error)
+	// Update replaces the data of a resume that is still at the given
+	// version and returns it at its new version
+	Update(ctx context.Context, id string, version int, data *models.ResumeRequest, note string) (*Resume, error)
+	// Delete removes a resume and its revisions. A zero version deletes it
+	// at any version.
+	Delete(ctx context.Context, id string, version int) error
+	// Revisions lists the revisions of a resume, newest first, without
+	// their data
+	Revisions(ctx context.Context, id string) ([]Revision, error)
+	// Revision loads one revision of a resume
+	Revision(ctx context.Context, id string, version int) (*Revision, error)
+	// CreateVariant saves a new variant of an existing resume at version 1
+	CreateVariant(ctx context.Context, v *Variant) error
+	// Variants lists the variants of a resume, oldest first
+	Variants(ctx context.Context, masterID string) ([]Variant, error)
+	// Variant loads a variant of a resume
+	Variant(ctx context.Context, masterID, id string) (*Variant, error)
+	// UpdateVariant replaces the definition of a variant that is still at
+	// the given version and returns it at its new version
+	UpdateVariant(ctx context.Context, masterID, id string, version int, data *models.Variant) (*Variant, error)
+	// DeleteVariant removes a variant. A zero version deletes it at any
+	// version.
+	DeleteVariant(ctx context.Context, masterID, id string, version int) error
+	// Close releases the underlying database
+	Close() error
+}
+
+// NewVariantID generates a variant ID
+func NewVariantID() (string, error) {
+	return randomString(12)
+}
+
+// NewCredentials generates a resume ID and its edit token
+func NewCredentials() (id, token string, err error) {
+	if id, err = randomString(16); err != nil {
+		return "", "", err
+	}
+	if token, err = randomString(32); err != nil {
+		return "", "", err
+	}
+	return id, token, nil
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the stored form of an edit token
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authorize reports whether token is the resume's edit token
+func (r *Resume) Authorize(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(HashToken(token)), []byte(r.TokenHash)) == 1
+}
@@ -0,0 +1,139 @@
+package variant
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// Stable IDs let variants select parts of a master resume across edits.
+// Sections carry "id", entries (including grouped positions) carry "id" in
+// their content, and bullet lists carry a parallel "bulletIds" list, so
+// consumers that only read the text are unaffected. Clients keep an ID with
+// its item when editing or reordering, and either send a bullet ID list of
+// the same length as its bullets or none at all.
+
+// entryKeys name the content lists whose items are entries
+var entryKeys = []string{"entries", "positions"}
+
+// entryLabels name an item of each entry list in error messages
+var entryLabels = map[string]string{"entries": "entry", "positions": "position"}
+
+// bulletKeys name the string lists that are bullets
+var bulletKeys = []string{"bullets", "description"}
+
+// bulletIDsKey names the list of bullet IDs parallel to the bullets
+const bulletIDsKey = "bulletIds"
+
+// AssignIDs gives every section, entry and bullet without an ID a new one.
+// Duplicate IDs are replaced. Bullet ID lists are expected to have passed
+// CheckBulletIDs; a missing list gets an ID per bullet.
+func AssignIDs(req *models.ResumeRequest) {
+	seen := make(map[string]bool)
+	unique := func(id, prefix string) string {
+		if id == "" || seen[id] {
+			id = newID(prefix, seen)
+		}
+		seen[id] = true
+		return id
+	}
+
+	for i := range req.Sections {
+		section := &req.Sections[i]
+		section.ID = unique(section.ID, "s")
+		if content, ok := section.Content.(map[string]interface{}); ok {
+			assignContentIDs(content, unique)
+		}
+	}
+}
+
+func assignContentIDs(m map[string]interface{}, unique func(id, prefix string) string) {
+	for _, key := range entryKeys {
+		items, _ := m[key].([]interface{})
+		for _, item := range items {
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, _ := entry["id"].(string)
+			entry["id"] = unique(id, "e")
+			assignContentIDs(entry, unique)
+		}
+	}
+
+	for _, key := range bulletKeys {
+		bullets, ok := m[key].([]interface{})
+		if !ok || !isStrings(bullets) {
+			continue
+		}
+		old, _ := m[bulletIDsKey].([]interface{})
+		ids := make([]interface{}, len(bullets))
+		for i := range bullets {
+			var id string
+			if i < len(old) {
+				id, _ = old[i].(string)
+			}
+			ids[i] = unique(id, "b")
+		}
+		m[bulletIDsKey] = ids
+	}
+}
+
+// CheckBulletIDs reports bullet ID lists whose length differs from their
+// bullets. Such a list cannot be matched to the bullets, as a bullet was
+// added, removed or moved without its ID.
+func CheckBulletIDs(req *models.ResumeRequest) []string {
+	var errors []string
+	for i, section := range req.Sections {
+		if content, ok := section.Content.(map[string]interface{}); ok {
+			errors = append(errors, checkContentBulletIDs(content, fmt.Sprintf("section %d", i+1))...)
+		}
+	}
+	return errors
+}
+
+func checkContentBulletIDs(m map[string]interface{}, path string) []string {
+	var errors []string
+	for _, key := range entryKeys {
+		items, _ := m[key].([]interface{})
+		for i, item := range items {
+			if entry, ok := item.(map[string]interface{}); ok {
+				errors = append(errors, checkContentBulletIDs(entry, fmt.Sprintf("%s, %s %d", path, entryLabels[key], i+1))...)
+			}
+		}
+	}
+
+	ids, ok := m[bulletIDsKey].([]interface{})
+	if !ok {
+		return errors
+	}
+	for _, key := range bulletKeys {
+		if bullets, ok := m[key].([]interface{}); ok && isStrings(bullets) && len(ids) != len(bullets) {
+			errors = append(errors, fmt.Sprintf("%s: %d bullet IDs for %d %s; keep each ID with its bullet or omit %s", path, len(ids), len(bullets), key, bulletIDsKey))
+		}
+	}
+	return errors
+}
+
+// newID returns a short random ID not in seen, e.g. "e-3kQ9xv0a"
+func newID(prefix string, seen map[string]bool) string {
+	for {
+		b := make([]byte, 6)
+		rand.Read(b)
+		id := prefix + "-" + base64.RawURLEncoding.EncodeToString(b)
+		if !seen[id] {
+			return id
+		}
+	}
+}
+
+func isStrings(l []interface{}) bool {
+	for _, v := range l {
+		if _, ok := v.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,264 @@
+package variant
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+// maxNameLength bounds a variant name
+const maxNameLength = 200
+
+// Validate checks a variant against its master. IDs that the master does
+// not contain are reported, as they would otherwise be silently ignored.
+func Validate(master *models.ResumeRequest, v *models.Variant) []string {
+	var errors []string
+
+	if strings.TrimSpace(v.Name) == "" {
+		errors = append(errors, "Variant name is required")
+	}
+	if len(v.Name) > maxNameLength {
+		errors = append(errors, fmt.Sprintf("Variant name must be at most %d characters", maxNameLength))
+	}
+
+	known := collectIDs(master)
+	for _, list := range []struct {
+		name string
+		ids  []string
+	}{{"include", v.Include}, {"exclude", v.Exclude}, {"sectionOrder", v.SectionOrder}} {
+		seen := make(map[string]bool)
+		for _, id := range list.ids {
+			kind, ok := known[id]
+			switch {
+			case !ok:
+				errors = append(errors, fmt.Sprintf("%s: the master resume has no item %q", list.name, id))
+			case list.name == "sectionOrder" && kind != "section":
+				errors = append(errors, fmt.Sprintf("sectionOrder: %q is not a section", id))
+			case seen[id]:
+				errors = append(errors, fmt.Sprintf("%s: %q is listed twice", list.name, id))
+			}
+			seen[id] = true
+		}
+	}
+	if v.Summary != nil {
+		switch v.Summary.Format {
+		case "paragraph", "bullets":
+		default:
+			errors = append(errors, "Summary format must be \"paragraph\" or \"bullets\"")
+		}
+	}
+
+	return errors
+}
+
+// collectIDs maps the IDs in a resume to the kind of item they name:
+// "section", "entry" or "bullet"
+func collectIDs(req *models.ResumeRequest) map[string]string {
+	ids := make(map[string]string)
+	var walk func(m map[string]interface{})
+	walk = func(m map[string]interface{}) {
+		for _, key := range entryKeys {
+			items, _ := m[key].([]interface{})
+			for _, item := range items {
+				if entry, ok := item.(map[string]interface{}); ok {
+					if id, _ := entry["id"].(string); id != "" {
+						ids[id] = "entry"
+					}
+					walk(entry)
+				}
+			}
+		}
+		bulletIDs, _ := m[bulletIDsKey].([]interface{})
+		for _, v := range bulletIDs {
+			if id, _ := v.(string); id != "" {
+				ids[id] = "bullet"
+			}
+		}
+	}
+
+	for _, section := range req.Sections {
+		if section.ID != "" {
+			ids[section.ID] = "section"
+		}
+		if content, ok := section.Content.(map[string]interface{}); ok {
+			walk(content)
+		}
+	}
+	return ids
+}
+
+// Apply returns the master resume as tailored by the variant. The master is
+// not modified. Unknown IDs are ignored, so a variant keeps working after
+// the items it names are deleted from the master.
+func Apply(master *models.ResumeRequest, v *models.Variant) (*models.ResumeRequest, error) {
+	// Work on a deep copy, as content is shared map data
+	data, err := json.Marshal(master)
+	if err != nil {
+		return nil, err
+	}
+	var result models.ResumeRequest
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	f := filter{include: set(v.Include), exclude: set(v.Exclude)}
+	var sections []models.Section
+	for _, section := range result.Sections {
+		content, _ := section.Content.(map[string]interface{})
+		if f.keep(section.ID, content, false) {
+			sections = append(sections, section)
+		}
+	}
+	result.Sections = reorder(sections, v.SectionOrder)
+
+	if v.Summary != nil {
+		applySummary(&result, v.Summary)
+	}
+	if v.PDF != nil {
+		result.PDF = v.PDF
+	}
+	return &result, nil
+}
+
+// filter decides which items a variant keeps. Without an include list every
+// item is kept unless excluded. With one, an item is kept when it, one of
+// its ancestors or one of its descendants is included, so including a
+// single bullet keeps its entry and section but drops the other bullets.
+type filter struct {
+	include map[string]bool
+	exclude map[string]bool
+}
+
+// keep filters the children of an item in place and reports whether the
+// item itself stays. inherited is set when an ancestor is included.
+func (f filter) keep(id string, m map[string]interface{}, inherited bool) bool {
+	if id != "" && f.exclude[id] {
+		return false
+	}
+	selected := len(f.include) == 0 || inherited || (id != "" && f.include[id])
+	if m == nil {
+		return selected
+	}
+
+	childKept := false
+	for _, key := range entryKeys {
+		items, ok := m[key].([]interface{})
+		if !ok {
+			continue
+		}
+		var kept []interface{}
+		for _, item := range items {
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				kept = append(kept, item)
+				continue
+			}
+			entryID, _ := entry["id"].(string)
+			if f.keep(entryID, entry, selected) {
+				kept = append(kept, entry)
+				childKept = true
+			}
+		}
+		m[key] = nonNil(kept)
+	}
+
+	for _, key := range bulletKeys {
+		bullets, ok := m[key].([]interface{})
+		if !ok {
+			continue
+		}
+		ids, _ := m[bulletIDsKey].([]interface{})
+		var keptBullets, keptIDs []interface{}
+		for i, bullet := range bullets {
+			var bulletID string
+			if i < len(ids) {
+				bulletID, _ = ids[i].(string)
+			}
+			if bulletID != "" && f.exclude[bulletID] {
+				continue
+			}
+			if selected || (bulletID != "" && f.include[bulletID]) {
+				keptBullets = append(keptBullets, bullet)
+				keptIDs = append(keptIDs, bulletID)
+				childKept = true
+			}
+		}
+		m[key] = nonNil(keptBullets)
+		if ids != nil {
+			m[bulletIDsKey] = nonNil(keptIDs)
+		}
+	}
+
+	return selected || childKept
+}
+
+// reorder moves the listed sections to the front, in the listed order
+func reorder(sections []models.Section, order []string) []models.Section {
+	if len(order) == 0 {
+		return sections
+	}
+	position := make(map[string]int)
+	for i, id := range order {
+		position[id] = i
+	}
+
+	front := make([]*models.Section, len(order))
+	var rest []models.Section
+	for i := range sections {
+		if p, ok := position[sections[i].ID]; ok && sections[i].ID != "" {
+			front[p] = &sections[i]
+		} else {
+			rest = append(rest, sections[i])
+		}
+	}
+
+	var result []models.Section
+	for _, s := range front {
+		if s != nil {
+			result = append(result, *s)
+		}
+	}
+	return append(result, rest...)
+}
+
+// applySummary replaces the content of the first profile summary, adding
+// one at the top when the master has none
+func applySummary(req *models.ResumeRequest, summary *models.ProfileSummaryContent) {
+	content := map[string]interface{}{"format": summary.Format}
+	if summary.Text != "" {
+		content["text"] = summary.Text
+	}
+	if len(summary.Bullets) > 0 {
+		bullets := make([]interface{}, len(summary.Bullets))
+		for i, b := range summary.Bullets {
+			bullets[i] = b
+		}
+		content["bullets"] = bullets
+	}
+
+	for i := range req.Sections {
+		if req.Sections[i].Type == "profile_summary" {
+			req.Sections[i].Content = content
+			return
+		}
+	}
+	req.Sections = append([]models.Section{{Type: "profile_summary", Content: content}}, req.Sections...)
+}
+
+func set(ids []string) map[string]bool {
+	m := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		m[id] = true
+	}
+	return m
+}
+
+// nonNil keeps emptied lists as [] rather than null in the JSON content
+func nonNil(l []interface{}) []interface{} {
+	if l == nil {
+		return []interface{}{}
+	}
+	return l
+}
@@ -0,0 +1,194 @@
+package variant
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sahil/ats-resume-maker/backend/internal/models"
+)
+
+const master = `{"basicDetails":{"firstName":"Jane"},"sections":[
+	{"id":"s-sum","type":"profile_summary","content":{"format":"paragraph","text":"Backend engineer"}},
+	{"id":"s-exp","type":"experience","content":{"entries":[
+		{"id":"e-acme","company":"Acme","bullets":["Built billing","Cut latency"],"bulletIds":["b-1","b-2"]},
+		{"id":"e-globex","company":"Globex","positions":[
+			{"id":"e-lead","title":"Lead","bullets":["Led team"],"bulletIds":["b-3"]},
+			{"id":"e-dev","title":"Developer","bullets":["Wrote code"],"bulletIds":["b-4"]}]}]}},
+	{"id":"s-edu","type":"education","content":{"entries":[{"id":"e-uni","institution":"State U"}]}}]}`
+
+func decode(t *testing.T, s string, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(s), v); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+}
+
+// outline lists the IDs left in a resume, e.g. "s-exp[e-acme[b-1]]"
+func outline(req *models.ResumeRequest) string {
+	var walk func(m map[string]interface{}) string
+	walk = func(m map[string]interface{}) string {
+		var parts []string
+		for _, key := range entryKeys {
+			items, _ := m[key].([]interface{})
+			for _, item := range items {
+				entry := item.(map[string]interface{})
+				parts = append(parts, entry["id"].(string)+walk(entry))
+			}
+		}
+		ids, _ := m[bulletIDsKey].([]interface{})
+		for _, id := range ids {
+			parts = append(parts, id.(string))
+		}
+		if len(parts) == 0 {
+			return ""
+		}
+		return "[" + strings.Join(parts, " ") + "]"
+	}
+
+	var sections []string
+	for _, s := range req.Sections {
+		content, _ := s.Content.(map[string]interface{})
+		id := s.ID
+		if id == "" {
+			id = s.Type
+		}
+		sections = append(sections, id+walk(content))
+	}
+	return strings.Join(sections, " ")
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant string
+		want    string
+	}{
+		{
+			name:    "empty variant keeps everything",
+			variant: `{"name":"All"}`,
+			want:    "s-sum s-exp[e-acme[b-1 b-2] e-globex[e-lead[b-3] e-dev[b-4]]] s-edu[e-uni]",
+		},
+		{
+			name:    "exclude entries, positions and bullets",
+			variant: `{"name":"Lean","exclude":["b-2","e-dev","s-edu"]}`,
+			want:    "s-sum s-exp[e-acme[b-1] e-globex[e-lead[b-3]]]",
+		},
+		{
+			name:    "include a bullet keeps its ancestors only",
+			variant: `{"name":"Focus","include":["b-3"]}`,
+			want:    "s-exp[e-globex[e-lead[b-3]]]",
+		},
+		{
+			name:    "include a section keeps its contents",
+			variant: `{"name":"Education","include":["s-edu","b-1"]}`,
+			want:    "s-exp[e-acme[b-1]] s-edu[e-uni]",
+		},
+		{
+			name:    "exclude wins over include",
+			variant: `{"name":"Mixed","include":["s-exp"],"exclude":["e-globex"]}`,
+			want:    "s-exp[e-acme[b-1 b-2]]",
+		},
+		{
+			name:    "section order and unknown IDs",
+			variant: `{"name":"Order","sectionOrder":["s-edu","s-gone"],"exclude":["b-gone"]}`,
+			want:    "s-edu[e-uni] s-sum s-exp[e-acme[b-1 b-2] e-globex[e-lead[b-3] e-dev[b-4]]]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m models.ResumeRequest
+			var v models.Variant
+			decode(t, master, &m)
+			decode(t, tt.variant, &v)
+			before := outline(&m)
+
+			got, err := Apply(&m, &v)
+			if err != nil {
+				t.Fatalf("Apply error = %v", err)
+			}
+			if o := outline(got); o != tt.want {
+				t.Errorf("Apply = %s, want %s", o, tt.want)
+			}
+			if outline(&m) != before {
+				t.Error("Apply modified the master")
+			}
+		})
+	}
+}
+
+func TestApplySummary(t *testing.T) {
+	var m models.ResumeRequest
+	decode(t, master, &m)
+	v := &models.Variant{Name: "Summary", Exclude: []string{"s-sum"}, Summary: &models.ProfileSummaryContent{Format: "bullets", Bullets: []string{"Go", "Kafka"}}}
+
+	got, err := Apply(&m, v)
+	if err != nil {
+		t.Fatalf("Apply error = %v", err)
+	}
+	if got.Sections[0].Type != "profile_summary" {
+		t.Fatalf("first section is %q, want the added summary", got.Sections[0].Type)
+	}
+	want := map[string]interface{}{"format": "bullets", "bullets": []interface{}{"Go", "Kafka"}}
+	if !reflect.DeepEqual(got.Sections[0].Content, want) {
+		t.Errorf("summary content = %v, want %v", got.Sections[0].Content, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var m models.ResumeRequest
+	decode(t, master, &m)
+	v := &models.Variant{
+		Name:         "Checked",
+		Include:      []string{"b-1", "b-1", "b-9"},
+		SectionOrder: []string{"e-acme"},
+		Summary:      &models.ProfileSummaryContent{Format: "list"},
+	}
+	want := []string{
+		`include: "b-1" is listed twice`,
+		`include: the master resume has no item "b-9"`,
+		`sectionOrder: "e-acme" is not a section`,
+		`Summary format must be "paragraph" or "bullets"`,
+	}
+	if got := Validate(&m, v); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate = %q, want %q", got, want)
+	}
+}
+
+func TestCheckBulletIDs(t *testing.T) {
+	var m models.ResumeRequest
+	decode(t, master, &m)
+	if errs := CheckBulletIDs(&m); errs != nil {
+		t.Errorf("CheckBulletIDs(master) = %q", errs)
+	}
+
+	decode(t, `{"sections":[{"type":"experience","content":{"entries":[
+		{"bullets":["Only bullet"]},
+		{"positions":[{"bullets":["Kept"],"bulletIds":["b-1","b-2"]}]}]}}]}`, &m)
+	want := []string{"section 1, entry 2, position 1: 2 bullet IDs for 1 bullets; keep each ID with its bullet or omit bulletIds"}
+	if got := CheckBulletIDs(&m); !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckBulletIDs = %q, want %q", got, want)
+	}
+}
+
+func TestAssignIDs(t *testing.T) {
+	var m models.ResumeRequest
+	decode(t, `{"sections":[{"id":"s-1","type":"experience","content":{"entries":[
+		{"id":"e-1","bullets":["A","B"],"bulletIds":["b-1","b-2"]},
+		{"id":"e-1","bullets":["C"]}]}}]}`, &m)
+	AssignIDs(&m)
+
+	entries := m.Sections[0].Content.(map[string]interface{})["entries"].([]interface{})
+	first, second := entries[0].(map[string]interface{}), entries[1].(map[string]interface{})
+	if first["id"] != "e-1" || !reflect.DeepEqual(first["bulletIds"], []interface{}{"b-1", "b-2"}) {
+		t.Errorf("existing IDs changed: %v", first)
+	}
+	if id, _ := second["id"].(string); id == "e-1" || !strings.HasPrefix(id, "e-") {
+		t.Errorf("duplicate entry ID was not replaced: %q", id)
+	}
+	if ids, _ := second["bulletIds"].([]interface{}); len(ids) != 1 {
+		t.Errorf("new bullet IDs = %v, want one", ids)
+	}
+}
@@ -4,34 +4,69 @@ export interface BasicDetails {
     firstName: string;
     lastName: string;
     email: string;
+    phone?: string;
     city: string;
     province: string;
     github?: string;
     linkedin?: string;
     portfolio?: string;
+    links?: ContactLink[];
+    linkOrder?: string[];
+    linkLayout?: 'inline' | 'stacked';
+}
+
+export interface ContactLink {
+    kind?: 'github' | 'linkedin' | 'stackoverflow' | 'scholar' | 'orcid' | 'mastodon' | 'personal' | string;
+    label?: string;
+    url: string;
 }
 
 export interface ProfileSummaryContent {
     format: 'paragraph' | 'bullets';
     text?: string;
     bullets?: string[];
+    bulletIds?: string[];
+}
+
+export type SkillLevel = 'expert' | 'advanced' | 'intermediate' | 'beginner';
+
+export interface SkillItem {
+    name: string;
+    level?: SkillLevel;
 }
 
 export interface SkillCategory {
     name: string;
-    skills: string;
+    skills: string; // Comma-separated, e.g. "Go (Expert), k8s"
+    items?: SkillItem[];
 }
 
 export interface TechSkillsContent {
     categories: SkillCategory[];
+    layout?: 'table' | 'list' | 'levels';
+    normalizeSpelling?: boolean;
 }
 
 export interface ExperienceEntry {
+    id?: string; // Stable ID, assigned when the resume is saved
+    bulletIds?: string[]; // Parallel to bullets
     company: string;
     title: string;
     location: string;
     startDate: string;
     endDate: string;
+    current?: boolean;
+    bullets: string[];
+    positions?: ExperiencePosition[];
+}
+
+export interface ExperiencePosition {
+    id?: string; // Stable ID, assigned when the resume is saved
+    bulletIds?: string[]; // Parallel to bullets
+    title: string;
+    startDate: string;
+    endDate: string;
+    current?: boolean;
     bullets: string[];
 }
 
@@ -39,24 +74,37 @@ export interface ExperienceContent {
     entries: ExperienceEntry[];
 }
 
+export interface ProjectLink {
+    kind?: 'repo' | 'demo' | 'paper' | 'video' | 'docs' | 'other';
+    label?: string;
+    url: string;
+}
+
 export interface ProjectEntry {
+    id?: string; // Stable ID, assigned when the resume is saved
+    bulletIds?: string[]; // Parallel to description
     name: string;
     description: string[];
     technologies?: string;
     link?: string;
+    links?: ProjectLink[];
     date?: string;
 }
 
 export interface ProjectsContent {
     entries: ProjectEntry[];
+    techStyle?: 'suffix' | 'line' | 'hidden';
 }
 
 export interface VolunteerEntry {
+    id?: string; // Stable ID, assigned when the resume is saved
+    bulletIds?: string[]; // Parallel to bullets
     organization: string;
     title: string;
     location?: string;
     startDate: string;
     endDate: string;
+    current?: boolean;
     bullets: string[];
 }
 
@@ -65,48 +113,441 @@ export interface VolunteerContent {
 }
 
 export interface EducationEntry {
+    id?: string;
     institution: string;
     degree: string;
+    location?: string;
     startDate: string;
     endDate: string;
+    current?: boolean;
+    gpa?: string;
+    gpaScale?: string;
+    showGpa?: boolean;
+    honors?: string[];
+    minors?: string[];
+    coursework?: string[];
+    thesis?: string;
 }
 
 export interface EducationContent {
     entries: EducationEntry[];
 }
 
+export interface CertificationEntry {
+    id?: string;
+    name: string;
+    issuer?: string;
+    date?: string;
+    expiryDate?: string;
+    credentialId?: string;
+    url?: string;
+}
+
+export interface CertificationsContent {
+    entries: CertificationEntry[];
+}
+
+export interface AwardEntry {
+    id?: string;
+    title: string;
+    issuer?: string;
+    date?: string;
+    description?: string;
+}
+
+export interface AwardsContent {
+    entries: AwardEntry[];
+}
+
+export interface PublicationEntry {
+    id?: string;
+    title: string;
+    authors?: string[];
+    venue?: string;
+    year?: string;
+    doi?: string;
+    url?: string;
+}
+
+export interface PublicationsContent {
+    entries: PublicationEntry[];
+}
+
+export type LanguageProficiency =
+    | 'native'
+    | 'fluent'
+    | 'professional'
+    | 'intermediate'
+    | 'basic'
+    | 'A1' | 'A2' | 'B1' | 'B2' | 'C1' | 'C2';
+
+export interface LanguageEntry {
+    id?: string;
+    name: string;
+    proficiency?: LanguageProficiency;
+}
+
+export interface LanguagesContent {
+    entries: LanguageEntry[];
+}
+
+export interface InterestsContent {
+    items: string[];
+}
+
+export interface CustomRow {
+    key: string;
+    value: string;
+}
+
+export interface CustomEntry {
+    id?: string; // Stable ID, assigned when the resume is saved
+    bulletIds?: string[]; // Parallel to bullets
+    title: string;
+    subtitle?: string;
+    location?: string;
+    startDate?: string;
+    endDate?: string;
+    current?: boolean;
+    bullets?: string[];
+}
+
+export interface CustomContent {
+    layout: 'bullets' | 'table' | 'entries' | 'paragraph';
+    text?: string;
+    bullets?: string[];
+    bulletIds?: string[];
+    rows?: CustomRow[];
+    entries?: CustomEntry[];
+}
+
 export type SectionType =
     | 'profile_summary'
     | 'tech_skills'
     | 'experience'
     | 'projects'
     | 'volunteer'
-    | 'education';
+    | 'education'
+    | 'certifications'
+    | 'awards'
+    | 'publications'
+    | 'languages'
+    | 'interests'
+    | 'custom';
 
 export interface Section {
     id: string;
     type: SectionType;
+    title?: string;
+    sort?: 'none' | 'reverse_chronological';
+    showTenure?: boolean;
     content:
     | ProfileSummaryContent
     | TechSkillsContent
     | ExperienceContent
     | ProjectsContent
     | VolunteerContent
-    | EducationContent;
+    | EducationContent
+    | CertificationsContent
+    | AwardsContent
+    | PublicationsContent
+    | LanguagesContent
+    | InterestsContent
+    | CustomContent;
     visible: boolean;
 }
 
+export type DateStyle = 'short' | 'long' | 'numeric' | 'year';
+
+export type Locale = 'en' | 'fr' | 'de' | 'es' | 'hi';
+
 export interface ResumeData {
     basicDetails: BasicDetails;
     sections: Section[];
+    locale?: Locale | string;
+    dateStyle?: DateStyle;
+    lint?: LintConfig; // Runs the lint rules alongside compilation when set
+    pdf?: PDFOptions;
+}
+
+// PDF metadata and standards
+export interface Recipient {
+    name?: string;
+    title?: string;
+    address?: string[]; // Printed one line each, below the company
+}
+
+export interface CoverLetterRequest {
+    basicDetails: BasicDetails; // Same header as the resume
+    recipient: Recipient;
+    company: string;
+    date?: string; // "YYYY-MM-DD" or free text; defaults to today
+    salutation?: string;
+    paragraphs: string[];
+    closing?: string;
+    locale?: string;
+    pdf?: PDFOptions;
+}
+
+export interface PDFOptions {
+    title?: string; // Defaults to "<name> Resume"
+    subject?: string; // Defaults to the most recent job title
+    keywords?: string[]; // Added after the skills listed in the resume
+    pdfa?: boolean; // PDF/A-2b output
+    tagged?: boolean; // Tagged PDF, when the TeX installation supports it
+}
+
+// Lint rules
+export type LintRule =
+    | 'bullet-metric'
+    | 'weak-opener'
+    | 'first-person'
+    | 'bullet-length'
+    | 'tense'
+    | 'missing-section'
+    | 'duplicate-bullet'
+    | 'date-format';
+
+export type LintSeverity = 'error' | 'warning' | 'info';
+
+export interface LintConfig {
+    disable?: LintRule[];
+    severity?: Partial<Record<LintRule, LintSeverity>>;
+    maxBulletWords?: number;
+    requiredSections?: SectionType[];
+}
+
+export interface LintLocation {
+    section: number; // 1-based
+    sectionType: SectionType;
+    entry?: number;
+    position?: number;
+    bullet?: number;
+    field?: string;
+}
+
+export interface LintIssue {
+    rule: LintRule;
+    severity: LintSeverity;
+    message: string;
+    location?: LintLocation;
 }
 
 // API Response types
+export interface BoxWarning {
+    kind: 'overfull' | 'underfull';
+    box: 'hbox' | 'vbox';
+    detail: string;
+    location?: string;
+}
+
+export interface SectionExtent {
+    type: SectionType;
+    startPage: number;
+    endPage: number;
+    height: number;
+    lines: number;
+}
+
+export interface LayoutMetrics {
+    pageCount: number;
+    lastPageFill: number;
+    overflowLines?: number;
+    overfullBoxes: number;
+    underfullBoxes: number;
+    warnings?: BoxWarning[];
+    sections?: SectionExtent[];
+}
+
+export interface ParseCheck {
+    id: string;
+    passed: boolean;
+    message: string;
+    details?: string[];
+}
+
+export interface ParseabilityReport {
+    score: number; // 0-100
+    checks: ParseCheck[];
+}
+
 export interface SuccessResponse {
     success: true;
     message: string;
     pdfUrl: string;
     pdfBase64?: string;
+    metrics?: LayoutMetrics;
+    parseability?: ParseabilityReport;
+    lint?: LintIssue[];
+    tagged?: boolean; // The PDF carries a structure tree
+}
+
+// Saved resumes
+export interface SavedResume {
+    id: string;
+    version: number; // Also sent as the ETag, e.g. "v3"
+    resume: ResumeData;
+    createdAt: string;
+    updatedAt: string;
+}
+
+export interface SavedResumeResponse {
+    success: true;
+    resume: SavedResume;
+    editToken?: string; // Only returned when the resume is created
+}
+
+// Revision history
+export interface Revision {
+    version: number;
+    note?: string;
+    createdAt: string;
+    resume?: ResumeData; // Omitted from revision listings
+}
+
+export interface RevisionsResponse {
+    success: true;
+    revisions: Revision[];
+}
+
+export interface RevisionResponse {
+    success: true;
+    revision: Revision;
+}
+
+export interface Change {
+    kind: 'added' | 'removed' | 'changed' | 'moved';
+    target: string; // e.g. 'section', 'entry', 'bullet' or 'field'
+    section?: string;
+    entry?: string;
+    field?: string;
+    index?: number; // 1-based, in the old revision for removals
+    before?: string;
+    after?: string;
+    summary: string;
+}
+
+export interface DiffResponse {
+    success: true;
+    from: number;
+    to: number;
+    changes: Change[];
+}
+
+// Per-application variants of a saved resume
+export interface Variant {
+    name: string;
+    include?: string[]; // IDs of the only sections, entries or bullets to keep
+    exclude?: string[]; // IDs of sections, entries or bullets to drop
+    sectionOrder?: string[]; // Section IDs rendered first
+    summary?: ProfileSummaryContent;
+    pdf?: PDFOptions;
+}
+
+export interface SavedVariant {
+    id: string;
+    masterId: string;
+    version: number; // Also sent as the ETag
+    variant: Variant;
+    resume?: ResumeData; // The variant applied to the current master
+    createdAt: string;
+    updatedAt: string;
+}
+
+export interface VariantResponse {
+    success: true;
+    variant: SavedVariant;
+}
+
+export interface VariantsResponse {
+    success: true;
+    variants: SavedVariant[];
+}
+
+// A draft read from another format, for review before saving
+export interface ImportResult {
+    resume: ResumeData;
+    confidence: Record<string, number>; // JSON path -> 0..1, e.g. "sections.2.content.entries.0.startDate"
+    warnings?: string[];
+}
+
+export interface ImportResponse {
+    success: true;
+    import: ImportResult;
+}
+
+// Either resumes, or one resume compiled once per override
+export interface BatchRequest {
+    resumes?: ResumeData[];
+    resume?: ResumeData;
+    overrides?: BatchOverride[];
+    concurrency?: number; // Capped by the server
+}
+
+export interface BatchOverride {
+    locale?: string;
+    dateStyle?: DateStyle;
+    pdf?: PDFOptions;
+}
+
+export type BatchItemState = 'compiled' | 'invalid' | 'failed' | 'canceled';
+
+export interface BatchItemStatus {
+    index: number;
+    name: string;
+    locale?: string;
+    status: BatchItemState;
+    file?: string; // PDF path in the archive
+    errors?: string[];
+}
+
+// manifest.json in the batch archive
+export interface BatchManifest {
+    total: number;
+    compiled: number;
+    failed: number;
+    items: BatchItemStatus[];
+}
+
+export type JobType = 'resume' | 'coverLetter' | 'batch';
+
+export interface JobRequest {
+    type: JobType;
+    resume?: ResumeData;
+    coverLetter?: CoverLetterRequest;
+    batch?: BatchRequest;
+}
+
+export type JobStatus = 'queued' | 'running' | 'done' | 'failed' | 'canceled';
+
+// Also the data of each event from /api/jobs/:id/events
+export interface Job {
+    id: string;
+    type: JobType;
+    status: JobStatus;
+    stage: string; // e.g. "queued", "generating", "pdflatex pass 1", "done"
+    completed?: number; // Finished items of a batch
+    total?: number;
+    result?: JobResult;
+    error?: string;
+    createdAt: string;
+    updatedAt: string;
+    expiresAt?: string;
+}
+
+export interface JobResult {
+    downloadUrl: string; // A PDF, or the ZIP archive of a batch
+    fileName: string;
+    metrics?: LayoutMetrics;
+    parseability?: ParseabilityReport;
+    lint?: LintIssue[];
+    tagged?: boolean;
+    manifest?: BatchManifest;
+}
+
+export interface JobResponse {
+    success: true;
+    job: Job;
 }
 
 export interface ErrorResponse {
@@ -116,3 +557,38 @@ export interface ErrorResponse {
 }
 
 export type ApiResponse = SuccessResponse | ErrorResponse;
+
+// Job description keyword match
+export interface AnalyzeRequest extends ResumeData {
+    jobDescription: string;
+}
+
+export interface KeywordMatch {
+    keyword: string;
+    skill: boolean;
+    count: number;
+    sections: string[];
+}
+
+export interface SectionCoverage {
+    type: SectionType;
+    title: string;
+    keywords: string[];
+}
+
+export interface AnalysisReport {
+    score: number;
+    keywords: KeywordMatch[];
+    missing: string[];
+    sections: SectionCoverage[];
+}
+
+export interface AnalyzeResponse {
+    success: true;
+    report: AnalysisReport;
+}
+
+export interface LintResponse {
+    success: true;
+    issues: LintIssue[];
+}